@@ -0,0 +1,63 @@
+package form
+
+import "github.com/tinywasm/model"
+
+// actionRule is one RequireOn/SkipOn declaration.
+type actionRule struct {
+	action  byte
+	field   string
+	require bool // false: skip
+}
+
+// RequireOn makes the named fields required for one CRUD action only
+// (model.ActionCreate, …) — a password set on create and left empty on
+// update to keep it. ValidateData rejects them empty for that action; the
+// other actions follow the input's own rules. On a delete it is the one
+// check made: a delete can require the fields it needs. Chainable.
+func (f *Form) RequireOn(action byte, fields ...string) *Form {
+	for _, name := range fields {
+		f.actionRules = append(f.actionRules, actionRule{action: action, field: name, require: true})
+	}
+	return f
+}
+
+// SkipOn leaves the named fields unvalidated by ValidateData for one action
+// — a write-once field an update legitimately leaves alone. Chainable.
+func (f *Form) SkipOn(action byte, fields ...string) *Form {
+	for _, name := range fields {
+		f.actionRules = append(f.actionRules, actionRule{action: action, field: name})
+	}
+	return f
+}
+
+// ruleFor reports whether field is required and whether it is skipped
+// for action.
+func (f *Form) ruleFor(action byte, field string) (required, skipped bool) {
+	for _, r := range f.actionRules {
+		if r.action != action || r.field != field {
+			continue
+		}
+		if r.require {
+			required = true
+		} else {
+			skipped = true
+		}
+	}
+	return required, skipped
+}
+
+// validateAction applies the action rules and the field rules of the i-th
+// input to val, for ValidateData. A delete validates nothing but the fields
+// RequireOn demands: the record is going away, and stale or incomplete data
+// must not block that.
+func (f *Form) validateAction(action byte, i int, val string, data model.Fielder) error {
+	name := f.Inputs[i].FieldName()
+	required, skipped := f.ruleFor(action, name)
+	if required && val == "" {
+		return errRequired(name)
+	}
+	if action == model.ActionDelete || skipped {
+		return nil
+	}
+	return f.validateField(i, val, data, true)
+}
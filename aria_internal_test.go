@@ -0,0 +1,84 @@
+package form
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tinywasm/dom"
+	"github.com/tinywasm/fmt"
+	"github.com/tinywasm/form/formtest"
+	"github.com/tinywasm/input"
+	"github.com/tinywasm/model"
+)
+
+type ariaStruct struct {
+	Nombre string
+	Role   string
+	Gender string
+	Tag    string
+	Custom string
+}
+
+func (s *ariaStruct) Schema() []model.Field {
+	return []model.Field{
+		{Name: "nombre", NotNull: true, Type: input.Text()},
+		{Name: "role", Type: input.Select()},
+		{Name: "gender", NotNull: true, Type: input.Radio()},
+		{Name: "tag", Type: input.Datalist()},
+		{Name: "custom", Type: &ariaWidget{}},
+	}
+}
+
+func (s *ariaStruct) Pointers() []any {
+	return []any{&s.Nombre, &s.Role, &s.Gender, &s.Tag, &s.Custom}
+}
+
+type ariaWidget struct{ input.Base }
+
+func (w *ariaWidget) Clone(parentID, name string) input.Input {
+	c := *w
+	c.InitBase(parentID, name, "text")
+	return &c
+}
+
+func (w *ariaWidget) RenderInput(value *dom.SignalString, onInput func(string)) *dom.Element {
+	return dom.NewElement("div").Attr("role", "textbox").Attr("contenteditable", "true")
+}
+
+func TestForm_Aria(t *testing.T) {
+	f, _ := New("app", &ariaStruct{}, &testIDGen{})
+	f.SetOptions("role", fmt.KeyValue{Key: "1", Value: "Admin"})
+	f.SetOptions("gender", fmt.KeyValue{Key: "m", Value: "Male"})
+	html := f.String()
+
+	if err := formtest.Aria(html, "app.form.nombre", "app.form.nombre.error", false, true); err != nil {
+		t.Error(err)
+	}
+	if err := formtest.Aria(html, "app.form.role", "app.form.role.error", false, false); err != nil {
+		t.Error(err)
+	}
+	if err := formtest.Aria(html, "app.form.tag", "app.form.tag.error", false, false); err != nil {
+		t.Error(err)
+	}
+
+	group := formtest.OpenTag(html, "app.form.gender.label")
+	if group == "" {
+		t.Fatalf("radio field label has no id: %s", html)
+	}
+	if !strings.Contains(html, "role='radiogroup'") ||
+		!strings.Contains(html, "aria-labelledby='app.form.gender.label'") {
+		t.Errorf("radio group missing role/aria-labelledby: %s", html)
+	}
+
+	// The custom widget's returned root gets the same wiring.
+	if !strings.Contains(html, "role='textbox'") ||
+		!strings.Contains(html, "aria-describedby='app.form.custom.error'") {
+		t.Errorf("custom Renderer root missing ARIA wiring: %s", html)
+	}
+
+	// aria-invalid follows the error signal.
+	f.errorSignals[0].Set("nombre is required")
+	if err := formtest.Aria(f.String(), "app.form.nombre", "app.form.nombre.error", true, true); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,42 @@
+//go:build !wasm
+
+package form
+
+import "github.com/tinywasm/dom"
+
+// bindNodes renders the current nodes of s into el. Server-side rendering
+// serialises static children only, so the nodes are added as such; nothing
+// re-renders there anyway.
+func bindNodes(el *dom.Element, s *dom.SignalNodes) *dom.Element {
+	for _, n := range s.Get() {
+		el.Child(n)
+	}
+	return el
+}
+
+// eventKey is the KeyboardEvent key of e; there are no key events outside
+// the browser.
+func eventKey(dom.Event) string { return "" }
+
+// eventComposing reports an IME composition in progress; never outside the
+// browser.
+func eventComposing(dom.Event) bool { return false }
+
+// eventDeleting reports an input event deleting text; there are none
+// outside the browser.
+func eventDeleting(dom.Event) bool { return false }
+
+// eventCaret is the caret position of e's target; unknown outside the
+// browser.
+func eventCaret(dom.Event) int { return -1 }
+
+// setTargetText rewrites e's target; there is none outside the browser.
+func setTargetText(dom.Event, string, int) {}
+
+// eventChord is the key chord of a keydown event (see normalizeChord);
+// there are no key events outside the browser.
+func eventChord(dom.Event) string { return "" }
+
+// eventPrevented reports whether a handler already called PreventDefault;
+// never outside the browser.
+func eventPrevented(dom.Event) bool { return false }
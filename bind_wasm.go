@@ -0,0 +1,123 @@
+//go:build wasm
+
+package form
+
+import (
+	"syscall/js"
+
+	"github.com/tinywasm/dom"
+	"github.com/tinywasm/fmt"
+)
+
+// bindNodes binds el's children to s, so every Set re-renders them in
+// place (options after SetOptions/LoadOptions, combobox results).
+func bindNodes(el *dom.Element, s *dom.SignalNodes) *dom.Element {
+	return el.BindChildren(s)
+}
+
+// jsEvent is the browser event underneath e, for what dom.Event lacks —
+// key, inputType, caret, files. The wasm event embeds its js.Value and is
+// reached through the promoted Get: dom's implementation, not its API. Every
+// event helper goes through jsEvent, and event.front_test.go fails when a
+// dom release stops matching it.
+func jsEvent(e dom.Event) (interface{ Get(string) js.Value }, bool) {
+	ev, ok := e.(interface{ Get(string) js.Value })
+	return ev, ok
+}
+
+// eventKey is the KeyboardEvent key of e ("ArrowDown", "Enter", "s", …).
+func eventKey(e dom.Event) string {
+	ev, ok := jsEvent(e)
+	if !ok {
+		return ""
+	}
+	k := ev.Get("key")
+	if k.Type() != js.TypeString {
+		return ""
+	}
+	return k.String()
+}
+
+// eventComposing reports whether e fired in the middle of an IME composition
+// (InputEvent.isComposing): the text is not final, so it must not be touched.
+func eventComposing(e dom.Event) bool {
+	ev, ok := jsEvent(e)
+	if !ok {
+		return false
+	}
+	c := ev.Get("isComposing")
+	return c.Type() == js.TypeBoolean && c.Bool()
+}
+
+// eventDeleting reports whether e is an input event deleting text
+// (InputEvent.inputType "deleteContentBackward", …) rather than inserting it.
+func eventDeleting(e dom.Event) bool {
+	ev, ok := jsEvent(e)
+	if !ok {
+		return false
+	}
+	t := ev.Get("inputType")
+	return t.Type() == js.TypeString && fmt.HasPrefix(t.String(), "delete")
+}
+
+// eventCaret is the caret position of e's target, in characters, or -1 when
+// the control has none.
+func eventCaret(e dom.Event) int {
+	ev, ok := jsEvent(e)
+	if !ok {
+		return -1
+	}
+	s := ev.Get("target").Get("selectionStart")
+	if s.Type() != js.TypeNumber {
+		return -1
+	}
+	return s.Int()
+}
+
+// setTargetText replaces the text of e's target and puts the caret at
+// caret. Bind leaves a focused control alone (cursor safety), so a mask
+// rewriting what the user is typing writes it itself.
+func setTargetText(e dom.Event, text string, caret int) {
+	ev, ok := jsEvent(e)
+	if !ok {
+		return
+	}
+	t := ev.Get("target")
+	if t.Get("value").String() != text {
+		t.Set("value", text)
+	}
+	if t.Get("selectionStart").Type() == js.TypeNumber {
+		t.Call("setSelectionRange", caret, caret)
+	}
+}
+
+// eventChord is the key chord of a keydown event in normalizeChord's
+// spelling ("ctrl+s", "escape"); Cmd counts as Ctrl.
+func eventChord(e dom.Event) string {
+	ev, ok := jsEvent(e)
+	if !ok {
+		return ""
+	}
+	key := eventKey(e)
+	if key == "" {
+		return ""
+	}
+	chord := ""
+	if ev.Get("ctrlKey").Truthy() || ev.Get("metaKey").Truthy() {
+		chord += "Ctrl+"
+	}
+	if ev.Get("altKey").Truthy() {
+		chord += "Alt+"
+	}
+	if ev.Get("shiftKey").Truthy() {
+		chord += "Shift+"
+	}
+	return normalizeChord(chord + key)
+}
+
+// eventPrevented reports whether a handler nearer the target already called
+// PreventDefault — the key was the control's, not the form's.
+func eventPrevented(e dom.Event) bool {
+	ev, ok := jsEvent(e)
+	return ok && ev.Get("defaultPrevented").Truthy()
+}
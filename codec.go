@@ -0,0 +1,395 @@
+package form
+
+import "github.com/tinywasm/fmt"
+
+// Codec translates between a field's display text — what the user sees and
+// the value signal holds — and the canonical text of its storage type, which
+// is what SyncValues parses into the struct pointer and what LoadValues reads
+// back out. It lets a date input sit on an int64 unix column, or a money
+// input on an integer count of cents, without the form guessing.
+//
+// Both directions must round-trip: FromStorage(ToStorage(s)) == s for any s
+// the input accepts. Neither is called with "" — empty always means the
+// storage zero value.
+//
+// A custom input implements Codec directly; WithCodec attaches one to a
+// built-in input by field name (and wins over the input's own).
+type Codec interface {
+	ToStorage(display string) (string, error)
+	FromStorage(stored string) (string, error)
+}
+
+type fieldCodec struct {
+	field string
+	codec Codec
+}
+
+// WithCodec attaches c to the named field. See Codec.
+func WithCodec(field string, c Codec) Option {
+	return func(f *Form) {
+		f.codecOverrides = append(f.codecOverrides, fieldCodec{field, c})
+	}
+}
+
+// resolveCodec picks the codec for a freshly cloned input: a WithCodec
+// override first, then the input's own, else nil.
+func (f *Form) resolveCodec(inp any, field string) Codec {
+	for _, fc := range f.codecOverrides {
+		if fc.field == field {
+			return fc.codec
+		}
+	}
+	if c, ok := inp.(Codec); ok {
+		return c
+	}
+	return nil
+}
+
+// toDisplay applies the i-th input's codec to a stored text, if it has one.
+func (f *Form) toDisplay(i int, stored string) string {
+	if stored == "" || f.codecs[i] == nil {
+		return stored
+	}
+	display, err := f.codecs[i].FromStorage(stored)
+	if err != nil {
+		return stored // show the raw value rather than blank the field
+	}
+	return display
+}
+
+// --- built-in codecs ---
+
+const secondsPerDay = 86400
+
+// UnixDate stores an <input type="date"> value ("2006-01-02") as unix
+// seconds at UTC midnight in an int field.
+func UnixDate() Codec { return unixCodec{withTime: false} }
+
+// UnixDateTime stores a datetime-local value ("2006-01-02T15:04:05", seconds
+// optional on input) as unix seconds, UTC, in an int field.
+func UnixDateTime() Codec { return unixCodec{withTime: true} }
+
+// RFC3339Date stores a date value as an RFC 3339 timestamp at UTC midnight
+// ("2006-01-02T00:00:00Z") in a text field.
+func RFC3339Date() Codec { return rfc3339Codec{withTime: false} }
+
+// RFC3339DateTime stores a datetime-local value as an RFC 3339 timestamp in
+// UTC ("2006-01-02T15:04:05Z") in a text field. A stored offset other than Z
+// is normalised to UTC on load.
+func RFC3339DateTime() Codec { return rfc3339Codec{withTime: true} }
+
+type unixCodec struct{ withTime bool }
+
+func (c unixCodec) ToStorage(display string) (string, error) {
+	secs, ok := parseDisplayTime(display, c.withTime)
+	if !ok {
+		return "", fmt.Err("date", "invalid")
+	}
+	return fmt.Convert(secs).String(), nil
+}
+
+func (c unixCodec) FromStorage(stored string) (string, error) {
+	secs, err := fmt.Convert(stored).Int64()
+	if err != nil {
+		return "", err
+	}
+	return formatDisplayTime(secs, c.withTime), nil
+}
+
+type rfc3339Codec struct{ withTime bool }
+
+func (c rfc3339Codec) ToStorage(display string) (string, error) {
+	secs, ok := parseDisplayTime(display, c.withTime)
+	if !ok {
+		return "", fmt.Err("date", "invalid")
+	}
+	return formatDisplayTime(secs, true) + "Z", nil
+}
+
+func (c rfc3339Codec) FromStorage(stored string) (string, error) {
+	secs, ok := parseRFC3339(stored)
+	if !ok {
+		return "", fmt.Err("date", "invalid")
+	}
+	return formatDisplayTime(secs, c.withTime), nil
+}
+
+// ScaledDecimal stores a decimal display value as an integer count of
+// 10^-scale units in an int field — money as cents with scale 2: "1234.5"
+// is stored as 123450 and shown back as "1234.50". A value with more
+// fractional digits than scale is rejected, never rounded.
+func ScaledDecimal(scale int) Codec { return scaledCodec{scale} }
+
+type scaledCodec struct{ scale int }
+
+func (c scaledCodec) ToStorage(display string) (string, error) {
+	s, neg := display, false
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	whole, frac := s, ""
+	if i := fmt.Index(s, "."); i >= 0 {
+		whole, frac = s[:i], s[i+1:]
+	}
+	for len(frac) > c.scale && frac[len(frac)-1] == '0' {
+		frac = frac[:len(frac)-1]
+	}
+	if (whole == "" && frac == "") || len(frac) > c.scale || !allDigits(whole) || !allDigits(frac) {
+		return "", fmt.Err("decimal", "invalid")
+	}
+	for len(frac) < c.scale {
+		frac += "0"
+	}
+	digits := trimLeadingZeros(whole + frac)
+	if neg && digits != "0" {
+		digits = "-" + digits
+	}
+	return digits, nil
+}
+
+func (c scaledCodec) FromStorage(stored string) (string, error) {
+	s, sign := stored, ""
+	if s != "" && s[0] == '-' {
+		sign, s = "-", s[1:]
+	}
+	if s == "" || !allDigits(s) {
+		return "", fmt.Err("decimal", "invalid")
+	}
+	for len(s) <= c.scale {
+		s = "0" + s
+	}
+	if c.scale == 0 {
+		return sign + s, nil
+	}
+	cut := len(s) - c.scale
+	return sign + s[:cut] + "." + s[cut:], nil
+}
+
+// Base64 shows a []byte field as standard base64 (RFC 4648, padded), so
+// binary data — which a text control would mangle into U+FFFD on the first
+// edit — round-trips losslessly. Text that is not valid base64 is a
+// ConvertError.
+func Base64() Codec { return base64Codec{} }
+
+type base64Codec struct{}
+
+const base64Alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+
+func (base64Codec) FromStorage(stored string) (string, error) {
+	out := make([]byte, 0, (len(stored)+2)/3*4)
+	for i := 0; i < len(stored); i += 3 {
+		var chunk [3]byte
+		n := copy(chunk[:], stored[i:])
+		v := uint(chunk[0])<<16 | uint(chunk[1])<<8 | uint(chunk[2])
+		for j := 0; j < 4; j++ {
+			if j <= n {
+				out = append(out, base64Alphabet[v>>(18-6*j)&0x3f])
+			} else {
+				out = append(out, '=')
+			}
+		}
+	}
+	return string(out), nil
+}
+
+func (base64Codec) ToStorage(display string) (string, error) {
+	if len(display)%4 != 0 {
+		return "", fmt.Err("base64", "invalid")
+	}
+	out := make([]byte, 0, len(display)/4*3)
+	for i := 0; i < len(display); i += 4 {
+		var v uint
+		pad := 0
+		for j := 0; j < 4; j++ {
+			c := display[i+j]
+			k := fmt.Index(base64Alphabet, string(c))
+			switch {
+			case c == '=' && j >= 2 && i+4 == len(display):
+				pad++
+				k = 0
+			case k < 0 || pad > 0:
+				return "", fmt.Err("base64", "invalid")
+			}
+			v = v<<6 | uint(k)
+		}
+		out = append(out, byte(v>>16), byte(v>>8), byte(v))
+		out = out[:len(out)-pad]
+	}
+	return string(out), nil
+}
+
+func allDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func trimLeadingZeros(s string) string {
+	for len(s) > 1 && s[0] == '0' {
+		s = s[1:]
+	}
+	return s
+}
+
+// --- calendar arithmetic (proleptic Gregorian, UTC; no time package) ---
+
+// daysFromCivil returns days since 1970-01-01 for y-m-d (Howard Hinnant's
+// algorithm — exact over the whole int64 range we care about).
+func daysFromCivil(y, m, d int64) int64 {
+	if m <= 2 {
+		y--
+	}
+	era := y / 400
+	if y < 0 && y%400 != 0 {
+		era--
+	}
+	yoe := y - era*400
+	mp := (m + 9) % 12
+	doy := (153*mp+2)/5 + d - 1
+	doe := yoe*365 + yoe/4 - yoe/100 + doy
+	return era*146097 + doe - 719468
+}
+
+// civilFromDays is the inverse of daysFromCivil.
+func civilFromDays(z int64) (y, m, d int64) {
+	z += 719468
+	era := z / 146097
+	if z < 0 && z%146097 != 0 {
+		era--
+	}
+	doe := z - era*146097
+	yoe := (doe - doe/1460 + doe/36524 - doe/146096) / 365
+	y = yoe + era*400
+	doy := doe - (365*yoe + yoe/4 - yoe/100)
+	mp := (5*doy + 2) / 153
+	d = doy - (153*mp+2)/5 + 1
+	m = mp + 3
+	if m > 12 {
+		m -= 12
+	}
+	if m <= 2 {
+		y++
+	}
+	return y, m, d
+}
+
+// num parses s[i:j] as a fixed-width unsigned decimal.
+func num(s string, i, j int) (int64, bool) {
+	if j > len(s) || !allDigits(s[i:j]) {
+		return 0, false
+	}
+	var n int64
+	for k := i; k < j; k++ {
+		n = n*10 + int64(s[k]-'0')
+	}
+	return n, true
+}
+
+// parseDisplayTime parses "2006-01-02" (withTime false) or
+// "2006-01-02T15:04[:05]" (withTime true) into unix seconds.
+func parseDisplayTime(s string, withTime bool) (int64, bool) {
+	if len(s) < 10 || s[4] != '-' || s[7] != '-' {
+		return 0, false
+	}
+	y, ok1 := num(s, 0, 4)
+	mo, ok2 := num(s, 5, 7)
+	d, ok3 := num(s, 8, 10)
+	if !ok1 || !ok2 || !ok3 || mo < 1 || mo > 12 || d < 1 || d > 31 {
+		return 0, false
+	}
+	if _, m2, d2 := civilFromDays(daysFromCivil(y, mo, d)); m2 != mo || d2 != d {
+		return 0, false // Feb 30 and friends
+	}
+	secs := daysFromCivil(y, mo, d) * secondsPerDay
+	if !withTime {
+		return secs, len(s) == 10
+	}
+	clock, ok := parseClock(s[10:])
+	return secs + clock, ok
+}
+
+// parseClock parses "T15:04" or "T15:04:05" into seconds since midnight.
+func parseClock(s string) (int64, bool) {
+	if (len(s) != 6 && len(s) != 9) || (s[0] != 'T' && s[0] != ' ') || s[3] != ':' {
+		return 0, false
+	}
+	h, ok1 := num(s, 1, 3)
+	mi, ok2 := num(s, 4, 6)
+	var sec int64
+	ok3 := true
+	if len(s) == 9 {
+		if s[6] != ':' {
+			return 0, false
+		}
+		sec, ok3 = num(s, 7, 9)
+	}
+	if !ok1 || !ok2 || !ok3 || h > 23 || mi > 59 || sec > 59 {
+		return 0, false
+	}
+	return h*3600 + mi*60 + sec, true
+}
+
+// parseRFC3339 parses "2006-01-02T15:04:05[.frac](Z|±hh:mm)" into unix
+// seconds (the fraction is dropped).
+func parseRFC3339(s string) (int64, bool) {
+	if len(s) < 20 {
+		return 0, false
+	}
+	secs, ok := parseDisplayTime(s[:19], true)
+	if !ok {
+		return 0, false
+	}
+	rest := s[19:]
+	if rest[0] == '.' {
+		i := 1
+		for i < len(rest) && rest[i] >= '0' && rest[i] <= '9' {
+			i++
+		}
+		rest = rest[i:]
+	}
+	if rest == "Z" || rest == "z" {
+		return secs, true
+	}
+	if len(rest) != 6 || (rest[0] != '+' && rest[0] != '-') || rest[3] != ':' {
+		return 0, false
+	}
+	oh, ok1 := num(rest, 1, 3)
+	om, ok2 := num(rest, 4, 6)
+	if !ok1 || !ok2 {
+		return 0, false
+	}
+	off := oh*3600 + om*60
+	if rest[0] == '+' {
+		return secs - off, true
+	}
+	return secs + off, true
+}
+
+// formatDisplayTime renders unix seconds as "2006-01-02" or
+// "2006-01-02T15:04:05" (UTC).
+func formatDisplayTime(secs int64, withTime bool) string {
+	days := secs / secondsPerDay
+	rem := secs % secondsPerDay
+	if rem < 0 {
+		days--
+		rem += secondsPerDay
+	}
+	y, m, d := civilFromDays(days)
+	out := pad(y, 4) + "-" + pad(m, 2) + "-" + pad(d, 2)
+	if withTime {
+		out += "T" + pad(rem/3600, 2) + ":" + pad(rem%3600/60, 2) + ":" + pad(rem%60, 2)
+	}
+	return out
+}
+
+func pad(n int64, width int) string {
+	s := fmt.Convert(n).String()
+	for len(s) < width {
+		s = "0" + s
+	}
+	return s
+}
@@ -0,0 +1,348 @@
+package form
+
+import (
+	"github.com/tinywasm/dom"
+	"github.com/tinywasm/fmt"
+	"github.com/tinywasm/input"
+	"github.com/tinywasm/widget"
+)
+
+// SearchProvider answers a combobox query with the matching options — Key
+// is what the field stores, Value the label the user sees. It calls done
+// once, synchronously or after a server round trip; answers to a query the
+// user has since typed past are dropped.
+type SearchProvider func(query string, done func(results []fmt.KeyValue))
+
+// combobox is the input behind Combobox: a text box that searches as the
+// user types and a listbox of results, following the WAI-ARIA combobox
+// pattern. Its value is the picked option's key; the box shows the label.
+// It renders through the Renderer contract, so the form still owns the
+// wrapper, the error span and validation.
+type combobox struct {
+	input.Base
+	search SearchProvider
+	// resolver looks up the label of a key no search has shown yet (a loaded
+	// record's id); creator builds new records. Both nil for a plain
+	// Combobox — see Reference.
+	resolver func(key string, done func(label string, err error))
+	creator  Creator
+
+	labels  []fmt.KeyValue    // key → label of every option seen so far
+	items   []fmt.KeyValue    // current results, in listbox order
+	results *dom.SignalNodes  // rendered items
+	open    *dom.SignalBool   // listbox shown
+	active  *dom.SignalString // id of the highlighted item ("" = none)
+	cursor  int               // index of the highlighted item, -1 = none
+	seq     int               // bumps per query; stale answers are dropped
+	relabel *dom.SignalBool   // toggled when a label for the value arrives
+
+	// Set by render: the form's value signal, the box's text and the
+	// callback storing a picked or cleared key.
+	value *dom.SignalString
+	text  *dom.SignalString
+	set   func(string)
+}
+
+// Combobox creates a searchable select for option sets too large for a
+// <select> or <datalist>: the field stores an option's key, shows its label,
+// and asks search for results as the user types. With a nil search it
+// filters its own Options (SetOptions) by label instead.
+func Combobox(search SearchProvider) input.Input {
+	c := &combobox{search: search}
+	c.InitBase("", "", "combobox")
+	return c
+}
+
+func (c *combobox) Clone(parentID, name string) input.Input {
+	cl := *c
+	cl.InitBase(parentID, name, "combobox")
+	cl.labels, cl.items = nil, nil
+	cl.results = dom.NewNodes()
+	cl.open = dom.NewBool(false)
+	cl.active = dom.NewString("")
+	cl.cursor, cl.seq = -1, 0
+	cl.relabel = dom.NewBool(false)
+	return &cl
+}
+
+// Validate accepts any key the search produced; a combobox with static
+// Options is a closed enum like a select.
+func (c *combobox) Validate(value string) error {
+	if value == "" {
+		if c.Required {
+			return errRequired(c.FieldName())
+		}
+		return nil
+	}
+	if len(c.Options) > 0 && !hasOption(c.Options, value) {
+		return errNotAllowed(c.FieldName(), value)
+	}
+	return nil
+}
+
+// labelOf returns the label for key: from the results seen so far, then the
+// static Options, else the key itself.
+func (c *combobox) labelOf(key string) string {
+	if label, ok := c.knownLabel(key); ok {
+		return label
+	}
+	return key
+}
+
+func (c *combobox) knownLabel(key string) (string, bool) {
+	if key == "" {
+		return "", true
+	}
+	for _, kv := range c.labels {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	for _, kv := range c.Options {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return "", false
+}
+
+// resolve asks the resolver for the label of a key no search has shown yet
+// and relabels the box once it arrives. A failed lookup leaves the key
+// showing.
+func (c *combobox) resolve(key string) {
+	if c.resolver == nil {
+		return
+	}
+	if _, ok := c.knownLabel(key); ok {
+		return
+	}
+	c.resolver(key, func(label string, err error) {
+		if err != nil || label == "" {
+			return
+		}
+		c.remember(fmt.KeyValue{Key: key, Value: label})
+		c.relabel.Set(!c.relabel.Get())
+	})
+}
+
+// remember caches the labels of kvs so a picked key keeps its label.
+func (c *combobox) remember(kvs ...fmt.KeyValue) {
+	for _, kv := range kvs {
+		if c.labelOf(kv.Key) == kv.Value {
+			continue
+		}
+		c.labels = append(c.labels, kv)
+	}
+}
+
+func (c *combobox) listID() string { return c.GetID() + ".listbox" }
+
+func (c *combobox) itemID(i int) string { return c.listID() + "." + fmt.Convert(i).String() }
+
+// pick stores kv's key and shows its label.
+func (c *combobox) pick(kv fmt.KeyValue) {
+	c.remember(kv)
+	c.close()
+	c.set(kv.Key)
+	c.text.Set(kv.Value) // same key picked again: no recompute
+}
+
+// query runs a search for q and shows its results.
+func (c *combobox) query(q string) {
+	c.seq++
+	seq := c.seq
+	show := func(results []fmt.KeyValue) {
+		if seq != c.seq {
+			return
+		}
+		c.items = results
+		c.remember(results...)
+		nodes := make([]*dom.Element, len(results))
+		for i, kv := range results {
+			nodes[i] = c.itemNode(i, kv)
+		}
+		c.results.Set(nodes)
+		c.highlight(-1)
+		c.open.Set(len(results) > 0)
+	}
+	if c.search != nil {
+		c.search(q, show)
+		return
+	}
+	lq := fmt.Convert(q).ToLower().String()
+	var matches []fmt.KeyValue
+	for _, kv := range c.Options {
+		if fmt.Index(fmt.Convert(kv.Value).ToLower().String(), lq) >= 0 { // "" lists all
+			matches = append(matches, kv)
+		}
+	}
+	show(matches)
+}
+
+func (c *combobox) itemNode(i int, kv fmt.KeyValue) *dom.Element {
+	id := c.itemID(i)
+	return dom.NewElement("li").
+		ID(id).
+		Key(kv.Key).
+		Attr("role", "option").
+		BindAttrFunc("aria-selected", func() string {
+			if c.active.Get() == id {
+				return "true"
+			}
+			return "false"
+		}).
+		Text(kv.Value).
+		// mousedown, not click: it lands before the input's blur closes
+		// the list.
+		On("mousedown", func(e dom.Event) {
+			e.PreventDefault()
+			c.pick(kv)
+		})
+}
+
+// highlight moves the active descendant to item i (-1 clears it).
+func (c *combobox) highlight(i int) {
+	c.cursor = i
+	if i < 0 || i >= len(c.items) {
+		c.cursor = -1
+		c.active.Set("")
+		return
+	}
+	c.active.Set(c.itemID(i))
+}
+
+func (c *combobox) close() {
+	c.open.Set(false)
+	c.highlight(-1)
+}
+
+// keyDown handles the combobox keys and reports whether the key's default
+// action must be prevented.
+func (c *combobox) keyDown(key string) bool {
+	switch key {
+	case "ArrowDown":
+		if !c.open.Get() {
+			c.query(c.text.Get())
+		} else {
+			c.highlight((c.cursor + 1) % len(c.items))
+		}
+		return true
+	case "ArrowUp":
+		if c.open.Get() {
+			if c.cursor <= 0 {
+				c.highlight(len(c.items) - 1)
+			} else {
+				c.highlight(c.cursor - 1)
+			}
+		}
+		return true
+	case "Enter":
+		if c.open.Get() && c.cursor >= 0 {
+			c.pick(c.items[c.cursor]) // pick, don't submit the form
+			return true
+		}
+	case "Escape":
+		if c.open.Get() {
+			c.close()
+			c.text.Set(c.labelOf(c.value.Get()))
+			return true
+		}
+	}
+	return false
+}
+
+// blur closes the list. Clearing the box clears the field; any other text
+// that was not picked from the list falls back to the current label.
+func (c *combobox) blur() {
+	c.close()
+	if c.text.Get() == "" {
+		if c.value.Get() != "" {
+			c.set("")
+		}
+		return
+	}
+	c.text.Set(c.labelOf(c.value.Get()))
+}
+
+// RenderInput satisfies Renderer, for a combobox rendered outside a Form; a
+// Form renders it through render, with the field's context.
+func (c *combobox) RenderInput(value *dom.SignalString, onInput func(string)) *dom.Element {
+	return c.render(value, onInput, nil)
+}
+
+// render builds the combobox in the WAI-ARIA 1.2 pattern: the text box is
+// the focusable element with the combobox role — so it also carries the
+// error wiring and the field's id, which the <label for> focuses — and it
+// controls the listbox. A hidden input posts the key under the field name
+// for server-rendered forms. set stores a picked (or cleared) key. fc, when
+// rendered by a Form, supplies the theme, the locked gate, the ARIA state
+// and translations; nil renders the bare widget.
+func (c *combobox) render(value *dom.SignalString, set func(string), fc *fieldComponent) *dom.Element {
+	listID := c.listID()
+	c.value, c.set = value, set
+	// What the box shows: the label of the stored key, recomputed when the
+	// key changes (pick, SetValues, LoadValues); typing overwrites it
+	// through the two-way binding until the next change.
+	c.text = dom.DeriveString(func() string {
+		c.relabel.Get()
+		return c.labelOf(value.Get())
+	})
+
+	class := widget.NameField.Class(widget.PartInput).String()
+	if fc != nil {
+		class = joinClass(class, fc.th().Input)
+	}
+	box := dom.NewElement("input").
+		Attr("type", "text").
+		ID(c.GetID()).
+		Class(class).
+		Attr("role", "combobox").
+		Attr("autocomplete", "off").
+		Attr("aria-autocomplete", "list").
+		Attr("aria-controls", listID).
+		BindAttrFunc("aria-expanded", func() string {
+			if c.open.Get() {
+				return "true"
+			}
+			return "false"
+		}).
+		BindAttrFunc("aria-activedescendant", c.active.Get).
+		Bind(c.text)
+	if fc != nil {
+		applyTextAttrs(box, fc)
+	} else if ph := c.GetPlaceholder(); ph != "" {
+		box.Attr("placeholder", ph)
+	}
+	if c.Required {
+		box.Attr("required", "")
+	}
+	if fc != nil {
+		box.BindAttrBoolFunc("disabled", fc.isDisabledOrLocked)
+		applyAria(box, fc)
+	} else if c.Disabled {
+		box.Attr("disabled", "")
+	}
+
+	box.On("input", func(e dom.Event) { c.query(e.TargetValue()) })
+	box.On("keydown", func(e dom.Event) {
+		if c.keyDown(eventKey(e)) {
+			e.PreventDefault()
+		}
+	})
+	box.On("blur", func(dom.Event) { c.blur() })
+
+	list := dom.NewElement("ul").
+		ID(listID).
+		Class(widget.NameField.Class("listbox").String()).
+		Attr("role", "listbox").
+		BindAttrBoolFunc("hidden", func() bool { return !c.open.Get() })
+
+	return dom.NewElement("div").
+		Class(widget.NameField.Class("combobox").String()).
+		Child(box).
+		Child(bindNodes(list, c.results)).
+		Child(dom.NewElement("input").
+			Attr("type", "hidden").
+			Attr("name", c.FieldName()).
+			BindAttrFunc("value", value.Get))
+}
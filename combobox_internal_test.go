@@ -0,0 +1,134 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/tinywasm/fmt"
+	"github.com/tinywasm/model"
+)
+
+type cityRecord struct{ City string }
+
+var cities = []fmt.KeyValue{
+	{Key: "SCL", Value: "Santiago"},
+	{Key: "VAP", Value: "Valparaíso"},
+	{Key: "VIN", Value: "Viña del Mar"},
+}
+
+func (r *cityRecord) Schema() []model.Field {
+	return []model.Field{{Name: "City", Type: Combobox(nil)}}
+}
+
+func (r *cityRecord) Pointers() []any { return []any{&r.City} }
+
+// renderedCombobox returns a rendered form over a static city list and its
+// combobox, with render's state wired.
+func renderedCombobox(t *testing.T) (*Form, *combobox) {
+	t.Helper()
+	f, err := New("app", &cityRecord{}, &testIDGen{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.SetOptions("City", cities...)
+	_ = f.String()
+	return f, f.Input("City").(*combobox)
+}
+
+func TestCombobox_KeyboardNavigation(t *testing.T) {
+	f, c := renderedCombobox(t)
+
+	if !c.keyDown("ArrowDown") || !c.open.Get() || len(c.items) != 3 {
+		t.Fatalf("ArrowDown on a closed box should open all results: open=%v items=%d", c.open.Get(), len(c.items))
+	}
+	if c.active.Get() != "" {
+		t.Errorf("nothing should be highlighted yet, got %q", c.active.Get())
+	}
+	c.keyDown("ArrowDown")
+	c.keyDown("ArrowDown")
+	if c.active.Get() != c.itemID(1) {
+		t.Errorf("active = %q, want %q", c.active.Get(), c.itemID(1))
+	}
+	c.keyDown("ArrowUp")
+	c.keyDown("ArrowUp") // wraps to the last item
+	if c.cursor != 2 {
+		t.Errorf("cursor = %d, want 2 after wrapping up", c.cursor)
+	}
+	if !c.keyDown("Enter") {
+		t.Error("Enter on a highlighted item must prevent the form submit")
+	}
+	if got := f.valueSignals[0].Get(); got != "VIN" {
+		t.Errorf("value = %q, want the key VIN", got)
+	}
+	if c.text.Get() != "Viña del Mar" || c.open.Get() || c.active.Get() != "" {
+		t.Errorf("after pick: text=%q open=%v active=%q", c.text.Get(), c.open.Get(), c.active.Get())
+	}
+	if c.keyDown("Enter") {
+		t.Error("Enter on a closed box must keep its default (submit)")
+	}
+}
+
+func TestCombobox_EscapeAndBlurRestoreLabel(t *testing.T) {
+	f, c := renderedCombobox(t)
+	f.SetValues("City", "SCL")
+	if c.text.Get() != "Santiago" {
+		t.Fatalf("text = %q, want the label of SCL", c.text.Get())
+	}
+
+	c.text.Set("viñ")
+	c.query("viñ")
+	if len(c.items) != 1 || c.items[0].Key != "VIN" {
+		t.Fatalf("local filter: got %v", c.items)
+	}
+	if !c.keyDown("Escape") || c.open.Get() || c.text.Get() != "Santiago" {
+		t.Errorf("Escape: open=%v text=%q", c.open.Get(), c.text.Get())
+	}
+
+	c.text.Set("nowhere")
+	c.blur()
+	if c.text.Get() != "Santiago" || f.valueSignals[0].Get() != "SCL" {
+		t.Errorf("blur with unpicked text: text=%q value=%q", c.text.Get(), f.valueSignals[0].Get())
+	}
+
+	c.text.Set("")
+	c.blur()
+	if f.valueSignals[0].Get() != "" {
+		t.Errorf("clearing the box should clear the field, value=%q", f.valueSignals[0].Get())
+	}
+}
+
+func TestCombobox_StaleSearchDropped(t *testing.T) {
+	var pending []func([]fmt.KeyValue)
+	c := Combobox(func(_ string, done func([]fmt.KeyValue)) {
+		pending = append(pending, done)
+	}).Clone("app", "City").(*combobox)
+
+	c.query("va")
+	c.query("vi")
+	pending[1]([]fmt.KeyValue{cities[2]})
+	pending[0]([]fmt.KeyValue{cities[1]}) // answers a query typed past
+	if len(c.items) != 1 || c.items[0].Key != "VIN" {
+		t.Errorf("items = %v, want only the latest answer", c.items)
+	}
+	if c.labelOf("VAP") != "VAP" {
+		t.Error("a stale answer must not be remembered either")
+	}
+	if c.labelOf("VIN") != "Viña del Mar" {
+		t.Errorf("labelOf(VIN) = %q", c.labelOf("VIN"))
+	}
+}
+
+func TestCombobox_PickAndClearCommit(t *testing.T) {
+	f, c := renderedCombobox(t)
+	changed := 0
+	f.OnFieldChange(func() { changed++ })
+
+	c.pick(cities[1])
+	if f.ChangedField() != "City" || changed != 1 {
+		t.Fatalf("a pick is a commit: ChangedField=%q changes=%d", f.ChangedField(), changed)
+	}
+	c.text.Set("")
+	c.blur()
+	if f.valueSignals[0].Get() != "" || changed != 2 {
+		t.Errorf("clearing the box commits the empty value: value=%q changes=%d", f.valueSignals[0].Get(), changed)
+	}
+}
@@ -0,0 +1,85 @@
+package form
+
+import (
+	"github.com/tinywasm/dom"
+	"github.com/tinywasm/widget"
+)
+
+// ComputeFunc derives a field's value from other fields: value returns the
+// current value of the named field (its value-signal text, "" for an
+// unknown name). Every field read through value becomes a dependency.
+type ComputeFunc func(value func(field string) string) string
+
+// Compute makes field a computed field: its value is fn of other fields,
+// recomputed live whenever one of them changes — an invoice total, a full
+// name, an age from a birth date. The field renders read-only and is never
+// set by the user, SetValues, LoadValues, Reset or Restore; SyncValues writes
+// it to the struct like any other field, and it validates like one. A
+// computed field may read other computed fields. It counts towards IsDirty
+// unless excluded with IgnoreDirty. Declare it before rendering. Chainable.
+func (f *Form) Compute(field string, fn ComputeFunc) *Form {
+	i := f.inputIndex(field)
+	if i < 0 {
+		return f
+	}
+	sig := dom.DeriveString(func() string { return fn(f.fieldValue) })
+	f.valueSignals[i] = sig
+	f.computed[i] = true
+	f.baseline[i] = sig.Get()
+	fc := f.children[i].(*fieldComponent)
+	fc.value = sig
+	fc.computed = true
+	f.rebase() // Modified tracks the new signal
+	return f
+}
+
+// IgnoreDirty leaves the named fields out of IsDirty — typically computed
+// fields, which change whenever their sources do and would otherwise report
+// every edit twice. Chainable.
+func (f *Form) IgnoreDirty(fields ...string) *Form {
+	for _, name := range fields {
+		if i := f.inputIndex(name); i >= 0 {
+			f.noDirty[i] = true
+		}
+	}
+	return f
+}
+
+// fieldValue is the value reader a ComputeFunc gets.
+func (f *Form) fieldValue(field string) string {
+	if i := f.inputIndex(field); i >= 0 {
+		return f.valueSignals[i].Get()
+	}
+	return ""
+}
+
+// rebaseComputed makes each computed field's current value its baseline,
+// once a load/reset has settled its sources.
+func (f *Form) rebaseComputed() {
+	for i, c := range f.computed {
+		if c {
+			f.baseline[i] = f.valueSignals[i].Get()
+		}
+	}
+}
+
+// renderComputed builds a computed field's read-only box, formatted when the
+// field has a Formatter.
+func (fc *fieldComponent) renderComputed() *dom.Element {
+	shown := fc.value
+	if fm := fc.formatter; fm != nil {
+		shown = dom.DeriveString(func() string { return fm.Format(fc.value.Get()) })
+	}
+	el := dom.NewElement("input").
+		ID(fc.Input.GetID()).
+		Class(joinClass(widget.NameField.Class(widget.PartInput).String(), fc.th().Input)).
+		Attr("name", fc.Input.FieldName()).
+		Attr("type", "text").
+		Attr("readonly", "")
+	if v := shown.Get(); v != "" {
+		el.Attr("value", v)
+	}
+	el.Bind(shown)
+	applyAria(el, fc)
+	return el
+}
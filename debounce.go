@@ -0,0 +1,117 @@
+package form
+
+// Timer schedules fn to run once after ms milliseconds; cancel stops it if
+// it has not run yet. The browser build uses setTimeout; tests inject a
+// manual clock with WithTimer.
+type Timer interface {
+	AfterFunc(ms int, fn func()) (cancel func())
+}
+
+// WithTimer replaces the timer debounced validation and OnFieldInput run
+// on. A nil Timer runs them at once — the default outside the browser.
+func WithTimer(t Timer) Option {
+	return func(f *Form) {
+		f.timer = t
+	}
+}
+
+type fieldDebounce struct {
+	field string // "" = the form default
+	ms    int
+}
+
+// WithDebounce delays live validation until the user has stopped typing in
+// the named fields for ms milliseconds — or, with no field names, in every
+// field without a delay of its own. For expensive custom validators. A
+// commit (leaving the field, a select/radio change) and Submit still
+// validate at once.
+func WithDebounce(ms int, fields ...string) Option {
+	return func(f *Form) {
+		if len(fields) == 0 {
+			f.debounces = append(f.debounces, fieldDebounce{ms: ms})
+		}
+		for _, name := range fields {
+			f.debounces = append(f.debounces, fieldDebounce{field: name, ms: ms})
+		}
+	}
+}
+
+// resolveDebounce picks a field's validation delay: its own, else the form
+// default.
+func (f *Form) resolveDebounce(field string) int {
+	ms, own := 0, false
+	for _, d := range f.debounces {
+		switch {
+		case d.field == field:
+			ms, own = d.ms, true
+		case d.field == "" && !own:
+			ms = d.ms
+		}
+	}
+	return ms
+}
+
+// OnFieldInput registers a callback fired as the user edits a field —
+// every keystroke, unlike OnFieldChange — with the field's name, once they
+// have paused for debounceMs milliseconds (0 = every keystroke). Each field
+// debounces on its own. Reset, LoadValues and Dispose drop a pending call.
+func (f *Form) OnFieldInput(debounceMs int, fn func(fieldName string)) *Form {
+	f.onFieldInput = fn
+	f.inputDelay = debounceMs
+	return f
+}
+
+// fieldInput runs the OnFieldInput callback for the i-th input.
+func (f *Form) fieldInput(i int) {
+	if f.onFieldInput == nil {
+		return
+	}
+	fc := f.children[i].(*fieldComponent)
+	name := fc.Input.FieldName()
+	fc.debounce(&fc.pendingInput, f.inputDelay, func() {
+		if f.onFieldInput != nil {
+			f.onFieldInput(name)
+		}
+	})
+}
+
+// cancelPending drops every field's pending debounced work.
+func (f *Form) cancelPending() {
+	for _, c := range f.children {
+		if fc, ok := c.(*fieldComponent); ok {
+			fc.stop(&fc.pendingCheck)
+			fc.stop(&fc.pendingInput)
+		}
+	}
+}
+
+// edited is the user changing the field's value to val: live validation as
+// its Trigger asks, and the OnFieldInput hook.
+func (fc *fieldComponent) edited(val string) {
+	fc.check(val, false)
+	if fc.onInput != nil {
+		fc.onInput()
+	}
+}
+
+// debounce runs fn after ms through the field's Timer, replacing the call
+// pending in slot; with no delay or no Timer it runs fn now.
+func (fc *fieldComponent) debounce(slot *func(), ms int, fn func()) {
+	fc.stop(slot)
+	if ms <= 0 || fc.timer == nil {
+		fn()
+		return
+	}
+	*slot = fc.timer.AfterFunc(ms, func() {
+		*slot = nil
+		fn()
+	})
+}
+
+// stop cancels the call pending in slot, if any.
+func (fc *fieldComponent) stop(slot *func()) {
+	if *slot != nil {
+		(*slot)()
+		*slot = nil
+	}
+}
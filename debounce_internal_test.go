@@ -0,0 +1,134 @@
+package form
+
+import "testing"
+
+// manualTimer is a Timer that fires only when the test advances it.
+type manualTimer struct {
+	now   int
+	tasks []*manualTask
+}
+
+type manualTask struct {
+	at   int
+	fn   func()
+	done bool
+}
+
+func (m *manualTimer) AfterFunc(ms int, fn func()) func() {
+	task := &manualTask{at: m.now + ms, fn: fn}
+	m.tasks = append(m.tasks, task)
+	return func() { task.done = true }
+}
+
+func (m *manualTimer) advance(ms int) {
+	m.now += ms
+	for _, task := range m.tasks {
+		if !task.done && task.at <= m.now {
+			task.done = true
+			task.fn()
+		}
+	}
+}
+
+func (m *manualTimer) pending() int {
+	n := 0
+	for _, task := range m.tasks {
+		if !task.done {
+			n++
+		}
+	}
+	return n
+}
+
+func TestDebounce_LiveValidation(t *testing.T) {
+	clock := &manualTimer{}
+	f, err := New("app", &triggerRecord{}, &testIDGen{}, WithTimer(clock), WithDebounce(300, "Name"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	name, nick := f.children[0].(*fieldComponent), f.children[1].(*fieldComponent)
+
+	name.value.Set("a")
+	name.edited("a")
+	clock.advance(200)
+	name.value.Set("ab")
+	name.edited("ab")
+	name.value.Set("a")
+	name.edited("a")
+	clock.advance(200)
+	if f.errorSignals[0].Get() != "" {
+		t.Fatal("validated before the user paused")
+	}
+	clock.advance(100)
+	if f.errorSignals[0].Get() == "" {
+		t.Fatal("validation should run 300ms after the last keystroke")
+	}
+
+	nick.value.Set("x")
+	nick.edited("x")
+	if f.errorSignals[1].Get() == "" {
+		t.Error("Nick has no delay: validates on the keystroke")
+	}
+
+	name.value.Set("abc")
+	name.edited("abc")
+	name.leave() // a commit validates now and supersedes the pending check
+	if f.errorSignals[0].Get() != "" || clock.pending() != 0 {
+		t.Errorf("leave: error %q, %d pending", f.errorSignals[0].Get(), clock.pending())
+	}
+}
+
+func TestDebounce_FieldInputCancelledOnReset(t *testing.T) {
+	clock := &manualTimer{}
+	f, _ := New("app", &triggerRecord{}, &testIDGen{}, WithTimer(clock), WithDebounce(300))
+	var calls []string
+	f.OnFieldInput(250, func(field string) { calls = append(calls, field) })
+	name, nick := f.children[0].(*fieldComponent), f.children[1].(*fieldComponent)
+
+	name.edited("a")
+	name.edited("ab")
+	nick.edited("n")
+	clock.advance(250)
+	if len(calls) != 2 || calls[0] != "Name" || calls[1] != "Nick" {
+		t.Fatalf("calls = %v, want one per field", calls)
+	}
+
+	name.value.Set("a")
+	name.edited("a")
+	f.Reset()
+	if clock.pending() != 0 {
+		t.Errorf("Reset left %d timers pending", clock.pending())
+	}
+	clock.advance(1000)
+	if len(calls) != 2 || f.errorSignals[0].Get() != "" {
+		t.Errorf("a cancelled timer fired: calls %v, error %q", calls, f.errorSignals[0].Get())
+	}
+
+	name.edited("b")
+	f.Dispose()
+	if clock.pending() != 0 {
+		t.Errorf("Dispose left %d timers pending", clock.pending())
+	}
+}
+
+func TestDebounce_CancelledOnRestore(t *testing.T) {
+	clock := &manualTimer{}
+	f, _ := New("app", &triggerRecord{}, &testIDGen{}, WithTimer(clock), WithDebounce(300))
+	var calls int
+	f.OnFieldInput(250, func(string) { calls++ })
+	saved := f.Snapshot()
+	name := f.children[0].(*fieldComponent)
+
+	name.value.Set("a")
+	name.edited("a")
+	if err := f.Restore(saved); err != nil {
+		t.Fatal(err)
+	}
+	if clock.pending() != 0 {
+		t.Errorf("Restore left %d timers pending", clock.pending())
+	}
+	clock.advance(1000)
+	if calls != 0 || f.errorSignals[0].Get() != "" {
+		t.Errorf("a timer from before the restore fired: calls %d, error %q", calls, f.errorSignals[0].Get())
+	}
+}
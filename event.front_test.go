@@ -0,0 +1,59 @@
+//go:build wasm
+
+package form
+
+import (
+	"syscall/js"
+	"testing"
+
+	"github.com/tinywasm/dom"
+)
+
+type eventProbe struct {
+	dom.Element
+	events []dom.Event
+}
+
+func (p *eventProbe) Render() *dom.Element {
+	keep := func(e dom.Event) { p.events = append(p.events, e) }
+	return dom.NewElement("input").ID("event-probe").On("keydown", keep).On("input", keep)
+}
+
+// TestJsEvent_DomEventExposesItsJSValue pins the assertion jsEvent makes on
+// dom's wasm event: when a dom release stops embedding the js.Value, every
+// key binding, mask and upload reads nothing — fail here instead.
+func TestJsEvent_DomEventExposesItsJSValue(t *testing.T) {
+	doc := js.Global().Get("document")
+	app := doc.Call("createElement", "div")
+	app.Set("id", "event-probe-app")
+	doc.Get("body").Call("appendChild", app)
+	p := &eventProbe{}
+	if err := dom.Render("event-probe-app", p); err != nil {
+		t.Fatal(err)
+	}
+	box := doc.Call("getElementById", "event-probe")
+
+	init := js.Global().Get("Object").New()
+	init.Set("key", "Escape")
+	init.Set("ctrlKey", true)
+	box.Call("dispatchEvent", js.Global().Get("KeyboardEvent").New("keydown", init))
+	init = js.Global().Get("Object").New()
+	init.Set("inputType", "deleteContentBackward")
+	box.Call("dispatchEvent", js.Global().Get("InputEvent").New("input", init))
+
+	if len(p.events) != 2 {
+		t.Fatalf("got %d events, want 2", len(p.events))
+	}
+	if _, ok := jsEvent(p.events[0]); !ok {
+		t.Fatal("jsEvent: dom.Event no longer exposes its js.Value through Get")
+	}
+	if got := eventKey(p.events[0]); got != "Escape" {
+		t.Errorf("eventKey = %q, want %q", got, "Escape")
+	}
+	if got := eventChord(p.events[0]); got != "ctrl+escape" {
+		t.Errorf("eventChord = %q, want %q", got, "ctrl+escape")
+	}
+	if !eventDeleting(p.events[1]) {
+		t.Error("eventDeleting = false, want true")
+	}
+}
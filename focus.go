@@ -0,0 +1,145 @@
+package form
+
+import "github.com/tinywasm/dom"
+
+// WithFocusSkip makes Focus, FocusField and a failed Submit pass over
+// fields the user cannot edit — disabled, read-only or computed ones, and
+// every field while the form is locked (see SetLocked) — instead of
+// focusing them anyway.
+func WithFocusSkip() Option {
+	return func(f *Form) {
+		f.focusSkip = true
+	}
+}
+
+// WithFocusTrap keeps keyboard focus inside the form, as a dialog does: Tab
+// on its last control (the submit button, unless hidden) wraps to the first
+// field, and Shift+Tab on the first field wraps to the last. Disabled
+// fields, and every field while the form is locked, are not stops. A
+// reference's "create new" form is trapped this way, its Cancel button
+// included.
+func WithFocusTrap() Option {
+	return func(f *Form) {
+		f.focusTrap = true
+	}
+}
+
+// FocusField moves keyboard focus to the named field, and records it as
+// the intent FocusedFieldID reports. A no-op for an unknown name, or a
+// field WithFocusSkip passes over. Chainable.
+func (f *Form) FocusField(fieldName string) *Form {
+	if i := f.inputIndex(fieldName); i >= 0 && f.focusable(i) {
+		f.focusAt(i)
+	}
+	return f
+}
+
+// focusable reports whether the i-th input may take focus.
+func (f *Form) focusable(i int) bool {
+	if !f.focusSkip {
+		return true
+	}
+	inp := f.Inputs[i]
+	return !f.locked.Get() && !inp.IsDisabled() && !inp.IsReadonly() && !f.computed[i]
+}
+
+// focusFirst focuses the first focusable input match accepts; false if
+// there is none.
+func (f *Form) focusFirst(match func(i int) bool) bool {
+	for i := range f.Inputs {
+		if f.focusable(i) && match(i) {
+			f.focusAt(i)
+			return true
+		}
+	}
+	return false
+}
+
+// focusAt records the i-th input as focused and moves DOM focus to its
+// control.
+func (f *Form) focusAt(i int) {
+	f.focused = f.Inputs[i].GetID()
+	if ref, ok := dom.Get(f.children[i].(*fieldComponent).controlID()); ok {
+		ref.Focus()
+	}
+}
+
+// tabStops lists, in order, the ids Tab visits in the form: each field's
+// control the user can reach, the submit button while it is enabled, then
+// trapLast.
+func (f *Form) tabStops() []string {
+	var stops []string
+	for i, inp := range f.Inputs {
+		if f.locked.Get() || inp.IsDisabled() || inp.HTMLName() == "hidden" {
+			continue
+		}
+		stops = append(stops, f.children[i].(*fieldComponent).controlID())
+	}
+	if !f.noSubmit && !f.submitting.Get() {
+		stops = append(stops, f.id+".submit")
+	}
+	if f.trapLast != "" {
+		stops = append(stops, f.trapLast)
+	}
+	return stops
+}
+
+// trapTarget is where the focus trap (see WithFocusTrap) sends a Tab chord
+// pressed on the element with id target, or "" to let the browser move on.
+func (f *Form) trapTarget(chord, target string) string {
+	if !f.focusTrap || (chord != "tab" && chord != "shift+tab") {
+		return ""
+	}
+	stops := f.tabStops()
+	if len(stops) == 0 {
+		return ""
+	}
+	first, last := stops[0], stops[len(stops)-1]
+	if chord == "tab" && target == last {
+		return first
+	}
+	if chord == "shift+tab" && target == first {
+		return last
+	}
+	return ""
+}
+
+// trapTab applies the focus trap to a keydown event; true when it moved
+// focus.
+func (f *Form) trapTab(e dom.Event) bool {
+	to := f.trapTarget(eventChord(e), e.TargetID())
+	if to == "" {
+		return false
+	}
+	e.PreventDefault()
+	e.StopPropagation()
+	for i := range f.Inputs {
+		if f.children[i].(*fieldComponent).controlID() == to {
+			f.focusAt(i)
+			return true
+		}
+	}
+	if ref, ok := dom.Get(to); ok {
+		ref.Focus()
+	}
+	return true
+}
+
+// focusInvalid focuses the first field showing an error — after a failed
+// Submit, so a keyboard user lands where the problem is.
+func (f *Form) focusInvalid() {
+	f.focusFirst(func(i int) bool { return f.errorSignals[i].Get() != "" })
+}
+
+// controlID is the id of the element that takes the field's focus: the
+// checked radio (or the first one) of a radio group, else the input's own.
+func (fc *fieldComponent) controlID() string {
+	if fc.Input.HTMLName() != "radio" || fc.computed {
+		return fc.Input.GetID()
+	}
+	key := fc.value.Get()
+	if opts := fc.Input.GetOptions(); key == "" && len(opts) > 0 {
+		key = opts[0].Key
+	}
+	return fc.Input.HandlerName() + "." + key
+}
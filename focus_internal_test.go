@@ -0,0 +1,35 @@
+package form
+
+import "testing"
+
+func TestFocusTrap_Wraps(t *testing.T) {
+	f, _ := New("app", &triggerRecord{}, &testIDGen{}, WithFocusTrap())
+	name, nick, submit := f.Inputs[0].GetID(), f.Inputs[1].GetID(), f.id+".submit"
+
+	cases := []struct{ chord, from, want string }{
+		{"tab", submit, name},
+		{"shift+tab", name, submit},
+		{"tab", name, ""}, // inside the form: the browser moves on
+		{"shift+tab", nick, ""},
+		{"ctrl+s", submit, ""},
+	}
+	for _, c := range cases {
+		if got := f.trapTarget(c.chord, c.from); got != c.want {
+			t.Errorf("%s on %s: got %q, want %q", c.chord, c.from, got, c.want)
+		}
+	}
+
+	f.HideSubmit()
+	if got := f.trapTarget("tab", nick); got != name {
+		t.Errorf("without a submit button the last field wraps: got %q", got)
+	}
+	f.SetLocked(true)
+	if got := f.trapTarget("tab", nick); got != "" {
+		t.Errorf("a locked form has no stops: got %q", got)
+	}
+
+	g, _ := New("app", &triggerRecord{}, &testIDGen{})
+	if got := g.trapTarget("tab", g.id+".submit"); got != "" {
+		t.Errorf("no trap without WithFocusTrap: got %q", got)
+	}
+}
@@ -17,6 +17,10 @@ type Form struct {
 	Inputs             []input.Input
 	fieldIndices       []int                            // Pre-computed struct field index per Input (-1 if not found)
 	class              string                           // CSS class(es)
+	theme              Theme                            // extra classes per anatomy part — see WithTheme
+	layout             FieldLayout                      // field wrapper markup — see WithLayout (nil = DefaultLayout)
+	translator         Translator                       // user-facing text per language — see WithTranslator
+	lang               *dom.SignalString                // language passed to translator — see SetLang
 	method             string                           // HTTP method (default POST)
 	action             string                           // Form action URL (default: struct name)
 	ssrMode            bool                             // Per-form SSR mode (default false)
@@ -32,12 +36,56 @@ type Form struct {
 	submitting         *dom.SignalBool                  // Global form submitting state
 	locked             *dom.SignalBool                  // Whole-form read-only gate (see SetLocked)
 	focused            string                           // id Focus() last targeted (see FocusedFieldID)
+	focusSkip          bool                             // focus passes over uneditable fields — see WithFocusSkip
+	focusTrap          bool                             // Tab wraps inside the form — see WithFocusTrap
+	trapLast           string                           // id of a stop after the submit button (a nested form's Cancel)
+	keys               []keyBinding                     // keyboard shortcuts — see WithKeyBinding
+	beforeValidate     func()                           // first step of Submit — see BeforeValidate
+	beforeSubmit       func(model.Fielder, func(bool))  // confirm/cancel gate — see BeforeSubmit
+	afterSuccess       func(model.Fielder)              // see AfterSuccess
+	afterError         func(error)                      // see AfterError
+	attempt            int                              // current submission; older dones are ignored
+	step               int                              // host-owned wizard step (see SetStep)
 	baseline           []string                         // last loaded/reset value per input — see IsDirty
 	showFields         []fmt.KeyValue                  // PK field names opted back in via ShowField — see New
 	hiddenPKIndices    []int                            // schema indices of PK fields New skipped — see sync.go
+	codecOverrides     []fieldCodec                     // per-field codecs from WithCodec — see codec.go
+	codecs             []Codec                          // one per input (nil = storage text as-is)
+	formatterOverrides []fieldFormatter                 // per-field masks from WithFormatter — see mask.go
+	nullable           []bool                           // per input: storage is a **T or Nullable — see null.go
+	nulls              []bool                           // per input: an empty value means NULL
+	baseNulls          []bool                           // NULL state at the baseline — see IsDirty
+	loaders            []*optionLoader                  // LoadOptions declarations, in call order
+	uploader           Uploader                         // file fields' upload backend — see WithUploader
+	pendingUploads     int                              // uploads in flight (see SelectFile)
+	submitQueued       bool                             // Submit called while uploads were pending
+	uploadFailed       bool                             // an upload failed since the queue was last drained
+	uploadEpoch        int                              // bumps when uploads are abandoned; their done is ignored
+	nested             bool                             // a reference's "create new" form — see CreateReference
+	computed           []bool                           // per input: value derived by Compute, never set
+	noDirty            []bool                           // per input: left out of IsDirty — see IgnoreDirty
+	constraints        []fieldConstraints               // per-field rules from WithConstraints — see rules.go
+	rules              []*fieldRules                    // per input: schema field + Constraints
+	unique             UniqueChecker                    // unique-field lookup — see WithUniqueChecker
+	actionRules        []actionRule                     // per-action required/skip rules — see RequireOn, SkipOn
+	groups             []fmt.KeyValue                   // (group, field) pairs — see Group
+	changed            string                           // field last committed — see ChangedField
+	triggers           []fieldTrigger                   // live-validation triggers from WithTrigger — see trigger.go
+	touched            []*dom.SignalBool                // per input: left at least once — see Touched
+	visited            []*dom.SignalBool                // per input: focused at least once — see Visited
+	active             []*dom.SignalBool                // per input: holds focus now — see Focused
+	modified           []*dom.SignalBool                // per input: differs from baseline — see Modified
+	rebased            *dom.SignalBool                  // toggled when baselines change — see state.go
+	timer              Timer                            // schedules debounced work — see WithTimer
+	debounces          []fieldDebounce                  // live-validation delays from WithDebounce
+	onFieldInput       func(string)                     // per-keystroke hook — see OnFieldInput
+	inputDelay         int                              // OnFieldInput debounce, in ms
 }
 
-// Option configures New. The only kind today is ShowField.
+// Option configures New (ShowField, WithTheme, WithLayout, WithTranslator,
+// WithCodec, WithFormatter, WithConstraints, WithUniqueChecker, WithTrigger,
+// WithDebounce, WithTimer, WithFocusSkip, WithFocusTrap, WithKeyBinding,
+// WithUploader).
 type Option func(*Form)
 
 // ShowField keeps the given primary-key field(s) in the rendered form
@@ -120,27 +168,35 @@ func (f *Form) SetLocked(v bool) *Form {
 // Focus moves keyboard focus to the form's first field — a host UI calls this
 // when entering an editable state (e.g. crudview's "+" / ⋮ Editar) so the user
 // can start typing immediately instead of having to click into the form. A
-// no-op if the form has no fields. Imperative, not reactive: the form's DOM
-// already exists by the time a host unlocks it (this never runs on first
-// mount), so a direct dom.Get+Focus is enough — no binding needed.
+// no-op if the form has no fields (or, with WithFocusSkip, no editable one).
+// Imperative, not reactive: the form's DOM already exists by the time a host
+// unlocks it (this never runs on first mount), so a direct dom.Get+Focus is
+// enough — no binding needed. See also FocusField.
 func (f *Form) Focus() *Form {
-	if len(f.Inputs) == 0 {
-		return f
-	}
-	f.focused = f.Inputs[0].GetID()
-	if ref, ok := dom.Get(f.focused); ok {
-		ref.Focus()
-	}
+	f.focusFirst(func(int) bool { return true })
 	return f
 }
 
-// FocusedFieldID returns the id Focus() last targeted (empty if never called,
-// or the form has no fields). Real focus movement is a WASM-only DOM side
-// effect (a no-op in the backend/SSR stub); this makes the INTENT observable
-// in any build, e.g. for the view/conformance "New/Edit focuses the first
-// field" clause to assert against without a live DOM.
+// FocusedFieldID returns the id Focus(), FocusField() or a failed Submit last
+// targeted (empty if never called, or the form has no fields). Real focus
+// movement is a WASM-only DOM side effect (a no-op in the backend/SSR stub);
+// this makes the INTENT observable in any build, e.g. for the
+// view/conformance "New/Edit focuses the first field" clause to assert
+// against without a live DOM.
 func (f *Form) FocusedFieldID() string { return f.focused }
 
+// SetStep records which step of a multi-step host (a wizard rendering this
+// form one page at a time) the user is on. The form never interprets it; it
+// only carries it so Snapshot/Restore can bring the user back to the same
+// page along with their values.
+func (f *Form) SetStep(step int) *Form {
+	f.step = step
+	return f
+}
+
+// Step returns the value last set via SetStep (or Restore). Zero by default.
+func (f *Form) Step() int { return f.step }
+
 // IsDirty reports whether any field's current value differs from the
 // baseline captured at the last load/reset (New, LoadValues, Reset). A host
 // uses this to gate persistence — e.g. crudview's auto-save on field commit
@@ -148,9 +204,12 @@ func (f *Form) FocusedFieldID() string { return f.focused }
 // write. Comparing valueSignals directly (not a struct diff) keeps this
 // exact and dependency-free: the signals are already the form's single
 // source of truth for "current value" everywhere else in this package.
+//
+// For a nullable field the NULL state counts too: a loaded empty string
+// turned into NULL with SetNull is a change even though the text is not.
 func (f *Form) IsDirty() bool {
-	for i, sig := range f.valueSignals {
-		if sig.Get() != f.baseline[i] {
+	for i := range f.valueSignals {
+		if !f.noDirty[i] && f.isModified(i) {
 			return true
 		}
 	}
@@ -163,7 +222,9 @@ func (f *Form) IsDirty() bool {
 func (f *Form) MarkPristine() {
 	for i, sig := range f.valueSignals {
 		f.baseline[i] = sig.Get()
+		f.baseNulls[i] = f.isNull(i)
 	}
+	f.rebase()
 }
 
 // OnFieldChange registers a callback fired every time a field is committed by the
@@ -175,15 +236,10 @@ func (f *Form) OnFieldChange(fn func()) *Form {
 	return f
 }
 
-// SetClass appends CSS classes to this form (on top of any global classes
-// set via SetGlobalClass). Chainable.
+// SetClass appends CSS classes to this form (on top of its theme's Form
+// classes — see WithTheme/SetDefaultTheme). Chainable.
 func (f *Form) SetClass(classes ...string) *Form {
-	for _, c := range classes {
-		if f.class != "" {
-			f.class += " "
-		}
-		f.class += c
-	}
+	f.class = joinClass(f.class, classes...)
 	return f
 }
 
@@ -223,7 +279,7 @@ func New(parentID string, data model.Fielder, idGen model.IDGenerator, opts ...O
 			"never construct its own generator (see model.IDGenerator's doc comment)")
 	}
 	schema := data.Schema()
-	values := model.ReadValues(schema, data.Pointers())
+	pointers := data.Pointers()
 
 	structName := resolveStructName(data)
 	formID := parentID + "." + structName
@@ -234,7 +290,8 @@ func New(parentID string, data model.Fielder, idGen model.IDGenerator, opts ...O
 		data:         data,
 		idGen:        idGen,
 		Inputs:       make([]input.Input, 0, len(schema)),
-		class:        globalClass,
+		class:        defaultTheme.Form,
+		theme:        defaultTheme,
 		method:       "POST",
 		action:       "/" + structName,
 		ssrMode:      false,
@@ -243,7 +300,11 @@ func New(parentID string, data model.Fielder, idGen model.IDGenerator, opts ...O
 		errorSignals: make([]*dom.SignalString, 0, len(schema)),
 		submitting:   dom.NewBool(false),
 		locked:       dom.NewBool(false),
+		lang:         dom.NewString(""),
 		baseline:     make([]string, 0, len(schema)),
+		timer:        defaultTimer(),
+		rebased:      dom.NewBool(false),
+		keys:         defaultKeys(),
 	}
 	for _, opt := range opts {
 		opt(f)
@@ -276,8 +337,16 @@ func New(parentID string, data model.Fielder, idGen model.IDGenerator, opts ...O
 			}
 		}
 
+		f.codecs = append(f.codecs, f.resolveCodec(inp, fieldName))
+		rules, err := f.resolveRules(field)
+		if err != nil {
+			return nil, fmt.Errf("form.New: %s: %v", fieldName, err)
+		}
+		f.rules = append(f.rules, rules)
+
 		// Initial value
-		val := fmt.Convert(values[i]).String()
+		stored := readField(pointers[i], field.Type.Storage())
+		val := f.toDisplay(len(f.codecs)-1, stored)
 
 		// Bind current value to input (still needed for SSR/initial state)
 		if setter, ok := inp.(interface{ SetValues(...string) }); ok {
@@ -291,15 +360,50 @@ func New(parentID string, data model.Fielder, idGen model.IDGenerator, opts ...O
 		f.valueSignals = append(f.valueSignals, vSig)
 		f.errorSignals = append(f.errorSignals, eSig)
 		f.baseline = append(f.baseline, val)
+		f.nullable = append(f.nullable, false)
+		f.nulls = append(f.nulls, false)
+		f.baseNulls = append(f.baseNulls, false)
+		f.computed = append(f.computed, false)
+		f.noDirty = append(f.noDirty, false)
+		f.touched = append(f.touched, dom.NewBool(false))
+		f.visited = append(f.visited, dom.NewBool(false))
+		f.active = append(f.active, dom.NewBool(false))
+		f.captureNull(len(f.nullable)-1, pointers[i], stored)
+		pos := len(f.Inputs) - 1
 		// A closure, not f.onFieldChange by value: OnFieldChange is meant to be
 		// called AFTER New() returns (chainable, like HideSubmit) — capturing the
 		// field directly here would freeze it at nil since registration happens
 		// later. The closure re-reads f.onFieldChange at commit time instead.
-		f.children = append(f.children, &fieldComponent{inp, vSig, eSig, f.locked, func() {
-			if f.onFieldChange != nil {
-				f.onFieldChange()
-			}
-		}})
+		f.children = append(f.children, &fieldComponent{
+			Input:  inp,
+			value:  vSig,
+			err:    eSig,
+			locked: f.locked,
+			theme:  &f.theme,
+			layout: f.layout,
+			tr:     f.Translate,
+			onCommit: func() {
+				f.changed = fieldName
+				f.fieldChanged(fieldName)
+				if f.onFieldChange != nil {
+					f.onFieldChange()
+				}
+			},
+			options:    dom.NewNodes(),
+			selectFile: func(file FileInfo) { f.SelectFile(fieldName, file) },
+			create:     func() { f.CreateReference(fieldName) },
+			nested:     dom.NewNodes(),
+			formatter:  f.resolveFormatter(inp, fieldName),
+			rules:      f.rules[len(f.rules)-1],
+			trigger:    f.resolveTrigger(fieldName),
+			touched:    f.touched[len(f.touched)-1],
+			visited:    f.visited[len(f.visited)-1],
+			active:     f.active[len(f.active)-1],
+			delay:      f.resolveDebounce(fieldName),
+			timer:      f.timer,
+			onInput:    func() { f.fieldInput(pos) },
+		})
+		f.resolveLabel(len(f.Inputs) - 1)
 		f.fieldIndices = append(f.fieldIndices, i)
 	}
 
@@ -309,6 +413,9 @@ func New(parentID string, data model.Fielder, idGen model.IDGenerator, opts ...O
 			"Definition (input.Text(), input.Number(), …) instead of model.Text()/model.Int()",
 			structName)
 	}
+	for i := range f.Inputs {
+		f.modified = append(f.modified, f.deriveModified(i))
+	}
 
 	forms = append(forms, f)
 	return f, nil
@@ -326,39 +433,81 @@ func (f *Form) Input(fieldName string) input.Input {
 	return nil
 }
 
-// SetOptions sets options for the input matching the given field name.
+// inputIndex returns the position of the named field in Inputs, or -1.
+func (f *Form) inputIndex(fieldName string) int {
+	for i, inp := range f.Inputs {
+		if inp.FieldName() == fieldName {
+			return i
+		}
+	}
+	return -1
+}
+
+// SetOptions sets options for the input matching the given field name. A
+// rendered select/radio/datalist re-renders its options in place. A select
+// or radio value that is not among the new options is cleared (a datalist's
+// options are only suggestions, so its value stays).
 func (f *Form) SetOptions(fieldName string, opts ...fmt.KeyValue) *Form {
-	inp := f.Input(fieldName)
-	if inp != nil {
-		if setter, ok := inp.(interface{ SetOptions(...fmt.KeyValue) }); ok {
-			setter.SetOptions(opts...)
+	i := f.inputIndex(fieldName)
+	if i < 0 {
+		return f
+	}
+	setter, ok := f.Inputs[i].(interface{ SetOptions(...fmt.KeyValue) })
+	if !ok {
+		return f
+	}
+	setter.SetOptions(opts...)
+	f.children[i].(*fieldComponent).refreshOptions()
+
+	switch f.Inputs[i].HTMLName() {
+	case "select", "radio":
+		if v := f.valueSignals[i].Get(); v != "" && !hasOption(opts, v) {
+			f.SetValues(fieldName, "")
 		}
 	}
 	return f
 }
 
-// Submit runs the full submit pipeline programmatically: syncs input values
-// into the bound struct, validates, and (if valid) fires the OnSubmit
-// callback. Returns the first validation error, or nil if the submission
-// was dispatched. The async result of the submission itself is delivered
-// through the OnSubmit callback's done function.
+// Submit runs the full submit pipeline programmatically: runs BeforeValidate,
+// syncs input values into the bound struct, validates, and (if valid) passes
+// the record through BeforeSubmit to the OnSubmit callback. Returns the first
+// validation error — or the ConvertErrors of a value that could not be
+// stored — or nil if the submission was dispatched. The async result of the
+// submission itself is delivered through the OnSubmit callback's done
+// function, then AfterSuccess or AfterError; CancelSubmit abandons it.
 func (f *Form) Submit() error {
-	// Sync all values from signals to struct
-	f.SyncValues(f.data)
+	// A file field's value is the reference its upload returns: submitting
+	// before that lands would send the old one. Hold the submission (button
+	// in its loading state) and let the last upload run it — see
+	// uploadSettled.
+	if f.pendingUploads > 0 {
+		f.submitQueued = true
+		f.submitting.Set(true)
+		return nil
+	}
+
+	if f.beforeValidate != nil {
+		f.beforeValidate()
+	}
 
-	// Validate all (final check)
+	// Sync all values from signals to struct. A value that didn't convert was
+	// never written, so the record would carry a stale field: don't send it.
+	if err := f.SyncValues(f.data); err != nil {
+		f.focusInvalid()
+		return err
+	}
+
+	// Validate all (final check). A failed attempt shows every field's
+	// error, whatever its Trigger, counts as having touched them all, and
+	// takes the user to the first one.
 	if err := f.Validate(); err != nil {
+		f.showErrors()
+		f.focusInvalid()
 		return err
 	}
 
 	if f.onSubmit != nil {
-		f.submitting.Set(true)
-		f.onSubmit(f.data, func(err error) {
-			f.submitting.Set(false)
-			if err == nil && !f.noResetOnSuccess {
-				f.reset()
-			}
-		})
+		f.dispatch()
 	}
 	return nil
 }
@@ -371,24 +520,42 @@ func (f *Form) resolveSubmitLabel() string {
 	if label == "" {
 		label = "Submit"
 	}
-	return label
+	return f.Translate(KeySubmit, label)
 }
 
 func (f *Form) reset() {
+	f.closeReferences()
+	f.cancelPending()
+	f.dropUploads()
 	for i, inp := range f.Inputs {
 		// Reset signals
-		f.valueSignals[i].Set("")
+		if !f.computed[i] {
+			f.valueSignals[i].Set("")
+		}
 		f.errorSignals[i].Set("")
-		f.baseline[i] = "" // a reset form is pristine — see IsDirty
+		f.baseline[i] = ""         // a reset form is pristine — see IsDirty
+		f.nulls[i] = f.nullable[i] // a new record's nullable fields start NULL
+		f.baseNulls[i] = f.nulls[i]
+		if fi, ok := inp.(*fileInput); ok {
+			fi.clear()
+		}
 
 		// Clear internal state (used by SSR/SyncValues if signals not available)
 		if setter, ok := inp.(interface{ SetValues(...string) }); ok {
 			setter.SetValues("")
 		}
 	}
+	f.rebaseComputed()
+	f.rebase()
+	f.untouch()
 	// A full reset also drops any pending focus intent — a host cancelling a
 	// draft (see crudview.undoAction) must leave nothing tracked as focused.
 	f.focused = ""
+	// Every parent is empty now: dependent options clear, and a load still
+	// in flight for the old parent is superseded — as after LoadValues.
+	for _, l := range f.loaders {
+		f.runLoader(l)
+	}
 }
 
 // SetValues sets values for the input matching the given field name.
@@ -396,6 +563,9 @@ func (f *Form) SetValues(fieldName string, values ...string) *Form {
 	for i, inp := range f.Inputs {
 		if getter, ok := inp.(interface{ FieldName() string }); ok {
 			if getter.FieldName() == fieldName {
+				if f.computed[i] {
+					break // derived from its sources — see Compute
+				}
 				val := ""
 				if len(values) > 0 {
 					val = values[0]
@@ -404,6 +574,8 @@ func (f *Form) SetValues(fieldName string, values ...string) *Form {
 				if setter, ok := inp.(interface{ SetValues(...string) }); ok {
 					setter.SetValues(values...)
 				}
+				f.resolveLabel(i)
+				f.fieldChanged(fieldName)
 				break
 			}
 		}
@@ -3,14 +3,92 @@ package form
 // Global storage for forms
 var forms = make([]*Form, 0)
 
-// Global class configuration
-var globalClass string
+// FormByID returns the live form whose html id is id (see GetID), or nil.
+// Scans newest first: a host that rebuilds a form under the same parent
+// without disposing the old one gets the one it just built.
+func FormByID(id string) *Form {
+	for i := len(forms) - 1; i >= 0; i-- {
+		if forms[i].id == id {
+			return forms[i]
+		}
+	}
+	return nil
+}
+
+// FormsByParent returns every live form mounted under parentID, oldest first.
+// A slice, not a single form: one parent element may host several (a record
+// form and its filter bar, say).
+func FormsByParent(parentID string) []*Form {
+	var out []*Form
+	for _, f := range forms {
+		if f.parentID == parentID {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// Forms returns every live form, oldest first. The slice is a copy — ranging
+// over it while disposing forms is safe.
+func Forms() []*Form {
+	out := make([]*Form, len(forms))
+	copy(out, forms)
+	return out
+}
 
-func SetGlobalClass(classes ...string) {
-	for _, c := range classes {
-		if globalClass != "" {
-			globalClass += " "
+// Dispose removes the form from the registry and drops everything that could
+// keep it (or the record it was built from) alive: value/error signals, the
+// bound data, rendered children and every registered callback. A host that
+// builds a form per selected record calls this when the record is closed —
+// New registers every form, and without Dispose nothing ever lets go of one.
+//
+// Dispose does not touch the DOM; the host unmounts the markup it mounted.
+// A disposed form must not be used again. Calling Dispose twice is a no-op.
+func (f *Form) Dispose() {
+	for i, g := range forms {
+		if g == f {
+			copy(forms[i:], forms[i+1:])
+			forms[len(forms)-1] = nil // the backing array must not pin it either
+			forms = forms[:len(forms)-1]
+			break
 		}
-		globalClass += c
 	}
+	f.closeReferences()
+	f.cancelPending()
+	f.dropUploads()  // a late done finds nothing to act on
+	f.CancelSubmit() // nor does a late OnSubmit done
+	f.data = nil
+	f.Inputs = nil
+	f.fieldIndices = nil
+	f.children = nil
+	f.valueSignals = nil
+	f.errorSignals = nil
+	f.baseline = nil
+	f.codecs = nil
+	f.nullable = nil
+	f.nulls = nil
+	f.baseNulls = nil
+	f.computed = nil
+	f.noDirty = nil
+	f.rules = nil
+	f.unique = nil
+	f.actionRules = nil
+	f.groups = nil
+	f.triggers = nil
+	f.touched = nil
+	f.visited = nil
+	f.active = nil
+	f.modified = nil
+	f.timer = nil
+	f.debounces = nil
+	f.onFieldInput = nil
+	f.keys = nil
+	f.beforeValidate = nil
+	f.beforeSubmit = nil
+	f.afterSuccess = nil
+	f.afterError = nil
+	f.onSubmit = nil
+	f.uploader = nil
+	f.loaders = nil
+	f.onFieldChange = nil
 }
@@ -0,0 +1,45 @@
+// Package formtest holds checks for tests of code that renders a form.
+// Each takes the rendered HTML (Form.String() or a host page around it) and
+// returns an error, so it fits any test framework:
+//
+//	if err := formtest.Aria(f.String(), "app.form.name", "app.form.name.error", false, true); err != nil {
+//		t.Error(err)
+//	}
+package formtest
+
+import "github.com/tinywasm/fmt"
+
+// Aria checks the ARIA wiring of the control with id in html: its
+// aria-describedby points at errorID, aria-invalid is "true" exactly when
+// invalid, and aria-required='true' is there exactly when required. nil
+// when all of it holds; otherwise the first mismatch, quoting the tag.
+func Aria(html, id, errorID string, invalid, required bool) error {
+	tag := OpenTag(html, id)
+	if tag == "" {
+		return fmt.Errf("no element with id %q in: %s", id, html)
+	}
+	state := "false"
+	if invalid {
+		state = "true"
+	}
+	for _, w := range []string{"aria-describedby='" + errorID + "'", "aria-invalid='" + state + "'"} {
+		if !fmt.Contains(tag, w) {
+			return fmt.Errf("%s: missing %s in %s", id, w, tag)
+		}
+	}
+	if got := fmt.Contains(tag, "aria-required='true'"); got != required {
+		return fmt.Errf("%s: aria-required present = %v, want %v in %s", id, got, required, tag)
+	}
+	return nil
+}
+
+// OpenTag returns the opening tag of the element with id in html — single
+// quoted, as the form renders it — or "" when there is none.
+func OpenTag(html, id string) string {
+	i := fmt.Index(html, "id='"+id+"'")
+	if i < 0 {
+		return ""
+	}
+	start := fmt.LastIndex(html[:i], "<")
+	return html[start : i+fmt.Index(html[i:], ">")+1]
+}
@@ -0,0 +1,28 @@
+package formtest_test
+
+import (
+	"testing"
+
+	"github.com/tinywasm/form/formtest"
+)
+
+func TestAria(t *testing.T) {
+	html := "<div><input id='f.name' aria-describedby='f.name.error' aria-invalid='false' aria-required='true'></div>"
+	if err := formtest.Aria(html, "f.name", "f.name.error", false, true); err != nil {
+		t.Errorf("well-wired control: %v", err)
+	}
+	for _, c := range []struct {
+		name              string
+		id, errorID       string
+		invalid, required bool
+	}{
+		{"missing element", "f.nick", "f.nick.error", false, true},
+		{"wrong error id", "f.name", "f.other.error", false, true},
+		{"invalid", "f.name", "f.name.error", true, true},
+		{"not required", "f.name", "f.name.error", false, false},
+	} {
+		if err := formtest.Aria(html, c.id, c.errorID, c.invalid, c.required); err == nil {
+			t.Errorf("%s: no error", c.name)
+		}
+	}
+}
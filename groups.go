@@ -0,0 +1,77 @@
+package form
+
+import "github.com/tinywasm/fmt"
+
+// Group tags the named fields with a group — a wizard step, a collapsible
+// section — for ValidateGroup. A field may belong to several groups.
+// Chainable.
+func (f *Form) Group(name string, fields ...string) *Form {
+	for _, field := range fields {
+		f.groups = append(f.groups, fmt.KeyValue{Key: name, Value: field})
+	}
+	return f
+}
+
+// ValidateGroup validates the fields tagged with the group name (see Group)
+// like ValidateFields. A name no field carries validates nothing.
+func (f *Form) ValidateGroup(name string) error {
+	var fields []string
+	for _, g := range f.groups {
+		if g.Key == name {
+			fields = append(fields, g.Value)
+		}
+	}
+	return f.ValidateFields(fields...)
+}
+
+// ValidateFields validates only the named fields and, unlike Validate, shows
+// the outcome: each field's error message is set, or cleared when it passes.
+// Every named field is checked; the first error is returned. Unknown names
+// are ignored. Auto-save validates just what the user committed with
+// f.ValidateFields(f.ChangedField()), or what differs from the baseline with
+// f.ValidateFields(f.DirtyFields()...).
+func (f *Form) ValidateFields(names ...string) error {
+	var first error
+	for _, name := range names {
+		i := f.inputIndex(name)
+		if i < 0 {
+			continue
+		}
+		if err := f.showValidation(i); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// showErrors validates every field, showing each outcome, and marks them
+// all touched — a failed Submit.
+func (f *Form) showErrors() {
+	for i := range f.Inputs {
+		f.showValidation(i)
+		f.touched[i].Set(true)
+	}
+}
+
+// showValidation validates the i-th input and sets or clears its error.
+func (f *Form) showValidation(i int) error {
+	err := f.validateAt(i)
+	f.setError(i, err)
+	return err
+}
+
+// ChangedField returns the name of the field the user last committed — the
+// one an OnFieldChange callback is running for. Empty before any commit.
+func (f *Form) ChangedField() string { return f.changed }
+
+// DirtyFields returns the names of the fields whose value differs from the
+// baseline, in form order — the per-field view of IsDirty.
+func (f *Form) DirtyFields() []string {
+	var names []string
+	for i := range f.valueSignals {
+		if !f.noDirty[i] && f.isModified(i) {
+			names = append(names, f.Inputs[i].FieldName())
+		}
+	}
+	return names
+}
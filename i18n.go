@@ -0,0 +1,65 @@
+package form
+
+// Translator supplies the user-facing text of a form in a given language.
+// Translate returns ok=false for a key it has no text for; the form then
+// keeps its own (untranslated) text, so a partial dictionary is fine.
+//
+// Keys:
+//
+//	"label.<field>"          field label (and its title tooltip)
+//	"option.<field>.<key>"   select/radio/datalist option label
+//	"placeholder.<field>"    the control's placeholder
+//	"title.<field>"          the control's title tooltip
+//	KeySubmit                submit button
+//	KeySubmitLoading         submit button while submitting
+//	KeyReferenceCreate       a reference field's "New" button
+//	KeyReferenceCancel       its nested form's "Cancel" button
+//	KeyError*                a validation message by kind — see Message; the
+//	                         text may interpolate {field}, {label}, {value}
+//	                         and {limit}
+//	<message>                any other validation message, keyed by its own
+//	                         text as the input's Validate produced it (also
+//	                         the fallback for a KeyError* the dictionary lacks)
+type Translator interface {
+	Translate(lang, key string) (text string, ok bool)
+}
+
+// Submit button message keys — see Translator.
+const (
+	KeySubmit        = "submit"
+	KeySubmitLoading = "submit.loading"
+)
+
+// WithTranslator injects the form's Translator. Per form, not package-wide:
+// a server rendering for several users at once builds each request's form
+// with that request's translator and language (SetLang).
+func WithTranslator(t Translator) Option {
+	return func(f *Form) {
+		f.translator = t
+	}
+}
+
+// SetLang switches the language passed to the Translator. Reactive: every
+// translated text of an already-rendered form (labels, options, submit
+// button, error messages) updates in place — no rebuild, no lost input.
+func (f *Form) SetLang(lang string) *Form {
+	f.lang.Set(lang)
+	return f
+}
+
+// Lang returns the language last set via SetLang (empty by default).
+func (f *Form) Lang() string { return f.lang.Get() }
+
+// Translate returns the text for key in the form's current language, or
+// fallback when there is no Translator or it has no entry. Hosts use it for
+// the error Submit returns, which reaches them untranslated.
+func (f *Form) Translate(key, fallback string) string {
+	lang := f.lang.Get() // read first: a binding calling this re-runs on SetLang
+	if f.translator == nil {
+		return fallback
+	}
+	if text, ok := f.translator.Translate(lang, key); ok {
+		return text
+	}
+	return fallback
+}
@@ -0,0 +1,152 @@
+package form
+
+import "github.com/tinywasm/fmt"
+
+type keyBinding struct {
+	chord  string
+	action func(*Form)
+	skip   func(f *Form, target string) bool // the key is the target's own
+}
+
+// defaultKeys are the bindings every form starts with: Ctrl+S and
+// Ctrl+Enter (a textarea's way out, where Enter is a newline) submit,
+// Escape reverts to the baseline — except in a control whose own Escape
+// closes something (see escapeIsOwn).
+func defaultKeys() []keyBinding {
+	submit := func(f *Form) { f.Submit() }
+	return []keyBinding{
+		{"ctrl+s", submit, nil},
+		{"ctrl+enter", submit, nil},
+		{"escape", func(f *Form) { f.Revert() }, (*Form).escapeIsOwn},
+	}
+}
+
+// escapeIsOwn reports whether Escape in the control with id target belongs
+// to it: a datalist's or combobox's suggestions, or a date or time picker,
+// which the browser may not report as handled. Reverting the whole form
+// there would throw away the edits the user only meant to dismiss a popup
+// over.
+func (f *Form) escapeIsOwn(target string) bool {
+	for i, inp := range f.Inputs {
+		if f.children[i].(*fieldComponent).controlID() != target {
+			continue
+		}
+		if _, ok := inp.(*combobox); ok {
+			return true
+		}
+		switch inp.HTMLName() {
+		case "datalist", "date", "time":
+			return true
+		}
+		return false
+	}
+	return false
+}
+
+// WithKeyBinding binds a key chord pressed anywhere in the form to action,
+// replacing any binding for the same chord; a nil action unbinds it (a
+// default included). A chord is modifiers and a key joined by "+", in any
+// order and case: "Ctrl+S", "shift+alt+k", "Escape". Ctrl also matches the
+// Mac's Cmd. Bindings are ignored while the form is locked or submitting,
+// and a key a control already handled (a combobox's Escape) never reaches
+// them. A binding of your own for Escape runs in every field, the ones the
+// default leaves alone included.
+func WithKeyBinding(chord string, action func(*Form)) Option {
+	return func(f *Form) {
+		chord = normalizeChord(chord)
+		for i := range f.keys {
+			if f.keys[i].chord == chord {
+				f.keys[i] = keyBinding{chord, action, nil}
+				return
+			}
+		}
+		f.keys = append(f.keys, keyBinding{chord, action, nil})
+	}
+}
+
+// handleKey runs the action bound to chord pressed in the control with id
+// target; false when nothing ran, so the key keeps its default behaviour.
+func (f *Form) handleKey(chord, target string) bool {
+	if chord == "" || f.locked.Get() || f.submitting.Get() {
+		return false
+	}
+	for _, k := range f.keys {
+		if k.chord == chord && k.action != nil {
+			if k.skip != nil && k.skip(f, target) {
+				return false
+			}
+			k.action(f)
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeChord spells a chord the one way handleKey compares: lower case,
+// modifiers as ctrl, alt, shift in that order, then the key.
+func normalizeChord(chord string) string {
+	var ctrl, alt, shift bool
+	key := ""
+	var parts []string
+	start := 0
+	for i := 0; i < len(chord); i++ {
+		// a "+" right after a separator is the plus key: "Ctrl++"
+		if chord[i] == '+' && i > start {
+			parts = append(parts, chord[start:i])
+			start = i + 1
+		}
+	}
+	parts = append(parts, chord[start:])
+	for _, p := range parts {
+		switch p = fmt.Convert(p).ToLower().String(); p {
+		case "ctrl", "control", "cmd", "meta":
+			ctrl = true
+		case "alt", "option":
+			alt = true
+		case "shift":
+			shift = true
+		case "esc":
+			key = "escape"
+		default:
+			key = p
+		}
+	}
+	out := ""
+	if ctrl {
+		out += "ctrl+"
+	}
+	if alt {
+		out += "alt+"
+	}
+	if shift {
+		out += "shift+"
+	}
+	return out + key
+}
+
+// Revert puts every field back to the baseline — the record as last
+// loaded, reset or saved (see IsDirty) — dropping the user's edits and any
+// error shown. Computed fields follow their sources. Escape's default
+// binding. Chainable.
+func (f *Form) Revert() *Form {
+	f.cancelPending()
+	for i, inp := range f.Inputs {
+		f.errorSignals[i].Set("")
+		if f.computed[i] {
+			continue
+		}
+		// Emptying a loaded value reads as NULL again, as after LoadValues.
+		f.nulls[i] = f.baseNulls[i] || (f.nullable[i] && f.baseline[i] != "")
+		if f.valueSignals[i].Get() == f.baseline[i] {
+			continue
+		}
+		f.valueSignals[i].Set(f.baseline[i])
+		if setter, ok := inp.(interface{ SetValues(...string) }); ok {
+			setter.SetValues(f.baseline[i])
+		}
+		f.resolveLabel(i)
+		f.fieldChanged(inp.FieldName())
+	}
+	f.rebase()
+	return f
+}
@@ -0,0 +1,99 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/tinywasm/input"
+	"github.com/tinywasm/model"
+)
+
+func TestNormalizeChord(t *testing.T) {
+	cases := map[string]string{
+		"Ctrl+S":         "ctrl+s",
+		"ctrl+s":         "ctrl+s",
+		"shift+CMD+k":    "ctrl+shift+k",
+		"Alt+Control+F2": "ctrl+alt+f2",
+		"esc":            "escape",
+		"Escape":         "escape",
+		"Ctrl++":         "ctrl++",
+		"Ctrl+Enter":     "ctrl+enter",
+	}
+	for in, want := range cases {
+		if got := normalizeChord(in); got != want {
+			t.Errorf("normalizeChord(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestHandleKey_DefaultsAndOverrides(t *testing.T) {
+	f, _ := New("app", &triggerRecord{Name: "Ann"}, &testIDGen{})
+	var submitted int
+	f.OnSubmit(func(model.Fielder, func(error)) { submitted++ })
+
+	f.SetValues("Name", "Bob")
+	if !f.handleKey("escape", f.Inputs[0].GetID()) || f.valueSignals[0].Get() != "Ann" || f.IsDirty() {
+		t.Errorf("Escape should revert to the baseline, got %q", f.valueSignals[0].Get())
+	}
+	if f.handleKey("ctrl+q", f.Inputs[0].GetID()) {
+		t.Error("an unbound chord is not handled")
+	}
+
+	f.SetValues("Nick", "bo")
+	if !f.handleKey("ctrl+s", f.Inputs[0].GetID()) || submitted != 1 {
+		t.Fatalf("Ctrl+S should submit (%d)", submitted)
+	}
+	// done never called: the form is still submitting
+	if f.handleKey("ctrl+enter", f.Inputs[0].GetID()) || submitted != 1 {
+		t.Error("bindings are ignored while submitting")
+	}
+
+	g, _ := New("app", &triggerRecord{}, &testIDGen{},
+		WithKeyBinding("escape", nil),
+		WithKeyBinding("ctrl+shift+r", func(f *Form) { f.Reset() }))
+	g.SetValues("Name", "Bob")
+	if g.handleKey("escape", g.Inputs[0].GetID()) {
+		t.Error("a nil action unbinds the default")
+	}
+	g.SetLocked(true)
+	if g.handleKey("ctrl+shift+r", g.Inputs[0].GetID()) || g.valueSignals[0].Get() != "Bob" {
+		t.Error("bindings are ignored while locked")
+	}
+	g.SetLocked(false)
+	if !g.handleKey("ctrl+shift+r", g.Inputs[0].GetID()) || g.valueSignals[0].Get() != "" {
+		t.Error("custom binding should run")
+	}
+}
+
+type escapeRecord struct{ Name, City, Day string }
+
+func (r *escapeRecord) Schema() []model.Field {
+	return []model.Field{
+		{Name: "Name", Type: input.Text()},
+		{Name: "City", Type: input.Datalist()},
+		{Name: "Day", Type: input.Date()},
+	}
+}
+
+func (r *escapeRecord) Pointers() []any { return []any{&r.Name, &r.City, &r.Day} }
+
+func TestHandleKey_EscapeLeavesControlsWithTheirOwn(t *testing.T) {
+	f, _ := New("app", &escapeRecord{}, &testIDGen{})
+	f.SetValues("Name", "Bob")
+	for _, field := range []string{"City", "Day"} {
+		id := f.Inputs[f.inputIndex(field)].GetID()
+		if f.handleKey("escape", id) || f.valueSignals[0].Get() != "Bob" {
+			t.Errorf("Escape in %s reverted the form: its Escape closes the control's popup", field)
+		}
+	}
+	if !f.handleKey("escape", f.Inputs[0].GetID()) || f.valueSignals[0].Get() != "" {
+		t.Error("Escape in a plain text box should revert")
+	}
+
+	// a binding of one's own runs everywhere
+	var ran int
+	g, _ := New("app", &escapeRecord{}, &testIDGen{},
+		WithKeyBinding("escape", func(*Form) { ran++ }))
+	if !g.handleKey("escape", g.Inputs[1].GetID()) || ran != 1 {
+		t.Error("a custom Escape binding should run in a datalist too")
+	}
+}
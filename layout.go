@@ -0,0 +1,73 @@
+package form
+
+import (
+	"github.com/tinywasm/dom"
+	"github.com/tinywasm/widget"
+)
+
+// FieldParts is what the form hands a FieldLayout for one field: every piece
+// already built, bound and wired (value binding, live validation, ids the
+// label's `for` and the error span rely on). A layout only decides how they
+// are wrapped and which extra classes they carry.
+type FieldParts struct {
+	// Class is the wrapper class the default markup uses: tw-field plus the
+	// theme's Field classes. A layout may use it, extend it or ignore it.
+	Class string
+	// Label is the field's <label>, or nil when the field has no label text.
+	Label *dom.Element
+	// Controls holds the interactive markup in document order: one element
+	// for most kinds (input, textarea, select, radio group, custom Renderer),
+	// two for a datalist (the input, then its <datalist>), and for a file
+	// field the picker, its <progress> and, with a preview, the <img>.
+	Controls []*dom.Element
+	// Error is the live error span (id = Input.ErrorID(), aria-live).
+	Error *dom.Element
+	// Invalid and Locked report the field's current state. Both read signals,
+	// so a layout passing them to BindClassFunc/BindStateFunc gets a reactive
+	// class — e.g. Bootstrap's is-invalid on the control.
+	Invalid func() bool
+	Locked  func() bool
+}
+
+// FieldLayout builds a field's markup from its parts. It sits one level above
+// Renderer: Renderer owns a single custom control, FieldLayout owns the
+// wrapper around label, control(s) and error for every field of a form —
+// the seam for CSS frameworks (Bootstrap's form-group/invalid-feedback,
+// Bulma's field/help, a design system's own anatomy).
+//
+// The returned element becomes the field component's root: the framework
+// injects the component id onto it, so a layout must return a fresh element
+// every call and must not reuse one across fields.
+type FieldLayout interface {
+	LayoutField(parts FieldParts) *dom.Element
+}
+
+// WithLayout renders every field of the form through l instead of
+// DefaultLayout. The submit button keeps its own wrapper.
+func WithLayout(l FieldLayout) Option {
+	return func(f *Form) {
+		f.layout = l
+	}
+}
+
+// DefaultLayout is the markup a form renders without WithLayout:
+// div.tw-field (carrying the data-invalid/data-locked state attributes) >
+// label, control(s), error span. Custom layouts can delegate to it and then
+// decorate the result.
+type DefaultLayout struct{}
+
+// LayoutField satisfies FieldLayout.
+func (DefaultLayout) LayoutField(p FieldParts) *dom.Element {
+	container := dom.NewElement("div").
+		Class(p.Class).
+		BindStateFunc(widget.Invalid, p.Invalid).
+		BindStateFunc(widget.Locked, p.Locked)
+	if p.Label != nil {
+		container.Child(p.Label)
+	}
+	for _, c := range p.Controls {
+		container.Child(c)
+	}
+	container.Child(p.Error)
+	return container
+}
@@ -1,9 +1,6 @@
 package form
 
-import (
-	"github.com/tinywasm/fmt"
-	"github.com/tinywasm/model"
-)
+import "github.com/tinywasm/model"
 
 // LoadValues populates every input from data, the inverse of SyncValues.
 // It is the operation a CRUD view needs when the user selects a record: one call,
@@ -17,26 +14,48 @@ func (f *Form) LoadValues(data model.Fielder) error {
 		return nil
 	}
 
-	values := model.ReadValues(data.Schema(), data.Pointers())
+	schema, pointers := data.Schema(), data.Pointers()
+	f.closeReferences()
+	f.cancelPending()
+	f.dropUploads()
 
 	for i, inp := range f.Inputs {
 		idx := f.fieldIndices[i]
-		if idx < 0 || idx >= len(values) {
+		if idx < 0 || idx >= len(pointers) {
 			continue
 		}
 
-		val := fmt.Convert(values[idx]).String()
+		if f.computed[i] {
+			continue // follows its sources; rebased below
+		}
+
+		stored := readField(pointers[idx], schema[idx].Type.Storage())
+		val := f.toDisplay(i, stored)
 
 		// Signal is the source of truth in WASM mode.
 		f.valueSignals[i].Set(val)
 		f.errorSignals[i].Set("") // loading a record clears stale validation errors
 		f.baseline[i] = val       // a freshly loaded record is pristine — see IsDirty
+		f.captureNull(i, pointers[idx], stored)
+		if fi, ok := inp.(*fileInput); ok {
+			fi.clear() // the loaded record's reference replaces any pick
+		}
 
 		// Keep input internal state in sync for SSR mode.
 		if setter, ok := inp.(interface{ SetValues(...string) }); ok {
 			setter.SetValues(val)
 		}
+		f.resolveLabel(i) // a reference shows the related record's label
 	}
 
+	f.rebaseComputed()
+	f.rebase()
+	f.untouch() // a new record starts untouched
+
+	// Option loaders run once every value is in, so a dependent field's
+	// loaded value is checked against options for its loaded parent.
+	for _, l := range f.loaders {
+		f.runLoader(l)
+	}
 	return nil
 }
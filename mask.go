@@ -0,0 +1,344 @@
+package form
+
+import (
+	"github.com/tinywasm/dom"
+	"github.com/tinywasm/fmt"
+)
+
+// Formatter masks a field while the user types: the box shows Format(value)
+// — "12.345.678-9", "$ 1.234,50" — while the value signal, validation and the
+// bound struct see the normalised value Normalize returns for what was typed.
+//
+// Normalize must accept any text, partial input included, and be idempotent
+// over its own output; Format(Normalize(s)) is what the box then shows. The
+// caret keeps its place among the characters Normalize keeps.
+//
+// A custom input implements Formatter directly; WithFormatter attaches one
+// to a built-in input by field name (and wins over the input's own).
+type Formatter interface {
+	Format(value string) string
+	Normalize(display string) string
+}
+
+type fieldFormatter struct {
+	field string
+	fm    Formatter
+}
+
+// WithFormatter attaches fm to the named field. See Formatter.
+func WithFormatter(field string, fm Formatter) Option {
+	return func(f *Form) {
+		f.formatterOverrides = append(f.formatterOverrides, fieldFormatter{field, fm})
+	}
+}
+
+// resolveFormatter picks the formatter for a freshly cloned input: a
+// WithFormatter override first, then the input's own, else nil.
+func (f *Form) resolveFormatter(inp any, field string) Formatter {
+	for _, ff := range f.formatterOverrides {
+		if ff.field == field {
+			return ff.fm
+		}
+	}
+	if fm, ok := inp.(Formatter); ok {
+		return fm
+	}
+	return nil
+}
+
+// bindMasked wires a masked control: it shows the formatted value, and each
+// input event normalises what was typed into the value signal and rewrites
+// the box formatted, with the caret where the user left it. Nothing is
+// touched mid IME composition; compositionend runs the same path once the
+// text is final.
+func (fc *fieldComponent) bindMasked(el *dom.Element) {
+	fm := fc.formatter
+	// Follows programmatic value changes (SetValues, LoadValues, Reset).
+	display := dom.DeriveString(func() string { return fm.Format(fc.value.Get()) })
+	if v := display.Get(); v != "" {
+		el.Attr("value", v)
+	}
+	el.Bind(display)
+
+	apply := func(e dom.Event) {
+		raw := e.TargetValue()
+		val := fm.Normalize(raw)
+		if eventDeleting(e) {
+			val = normalizeTyped(fm, raw)
+		}
+		shown := fm.Format(val)
+		caret := eventCaret(e)
+		if caret < 0 {
+			caret = runeLen(raw)
+		}
+		fc.value.Set(val)
+		display.Set(shown)
+		setTargetText(e, shown, maskCaret(fm, raw, caret, shown))
+		fc.edited(val)
+	}
+	el.On("input", func(e dom.Event) {
+		if !eventComposing(e) {
+			apply(e)
+		}
+	})
+	el.On("compositionend", apply)
+}
+
+// typedNormalizer is a Formatter whose Normalize reads text it did not
+// write — pasted, autofilled — differently from its own display edited in
+// place, which normalizeTyped reads.
+type typedNormalizer interface {
+	normalizeTyped(display string) string
+}
+
+// normalizeTyped normalises display as the mask's own text edited in place.
+func normalizeTyped(fm Formatter, display string) string {
+	if tn, ok := fm.(typedNormalizer); ok {
+		return tn.normalizeTyped(display)
+	}
+	return fm.Normalize(display)
+}
+
+// maskCaret maps a caret at rune index caret in raw to the same spot in
+// formatted: just past as many normalised characters as precede it in raw.
+// A caret at the end stays at the end.
+func maskCaret(fm Formatter, raw string, caret int, formatted string) int {
+	n := runeLen(formatted)
+	if caret >= runeLen(raw) {
+		return n
+	}
+	want := runeLen(normalizeTyped(fm, runePrefix(raw, caret)))
+	for p := 0; p <= n; p++ {
+		if runeLen(normalizeTyped(fm, runePrefix(formatted, p))) >= want {
+			return p
+		}
+	}
+	return n
+}
+
+func runeLen(s string) int {
+	n := 0
+	for range s {
+		n++
+	}
+	return n
+}
+
+// runePrefix returns the first n runes of s.
+func runePrefix(s string, n int) string {
+	i := 0
+	for pos := range s {
+		if i == n {
+			return s[:pos]
+		}
+		i++
+	}
+	return s
+}
+
+// --- built-in masks ---
+
+// RutMask formats a Chilean RUT as "12.345.678-9" and stores it as
+// "12345678-9" — the form input.Rut validates. The last character typed is
+// the check digit; a "K" is stored lower-case.
+func RutMask() Formatter { return rutMask{} }
+
+type rutMask struct{}
+
+func (rutMask) Normalize(display string) string {
+	var b []byte
+	for i := 0; i < len(display); i++ {
+		switch c := display[i]; {
+		case c >= '0' && c <= '9':
+			b = append(b, c)
+		case c == 'k' || c == 'K':
+			b = append(b, 'k')
+		}
+	}
+	if len(b) < 2 {
+		return string(b)
+	}
+	return string(b[:len(b)-1]) + "-" + string(b[len(b)-1:])
+}
+
+func (rutMask) Format(value string) string {
+	body, dv := value, ""
+	for i := 0; i < len(value); i++ {
+		if value[i] == '-' {
+			body, dv = value[:i], value[i:]
+			break
+		}
+	}
+	return groupThousands(body, ".") + dv
+}
+
+// PatternMask fills digits into the '#' slots of pattern — "(###) ###-####",
+// "### ### ###" — and stores the digits alone. Pattern characters other
+// than '#' are inserted as the user reaches them; they must not be digits.
+// Digits beyond the last slot are dropped.
+func PatternMask(pattern string) Formatter { return patternMask{pattern} }
+
+type patternMask struct{ pattern string }
+
+func (m patternMask) Normalize(display string) string {
+	slots := 0
+	for i := 0; i < len(m.pattern); i++ {
+		if m.pattern[i] == '#' {
+			slots++
+		}
+	}
+	var b []byte
+	for i := 0; i < len(display) && len(b) < slots; i++ {
+		if c := display[i]; c >= '0' && c <= '9' {
+			b = append(b, c)
+		}
+	}
+	return string(b)
+}
+
+func (m patternMask) Format(value string) string {
+	if value == "" {
+		return ""
+	}
+	out, d := "", 0
+	for _, c := range m.pattern {
+		if d == len(value) {
+			break
+		}
+		if c == '#' {
+			out += value[d : d+1]
+			d++
+			continue
+		}
+		out += string(c)
+	}
+	return out
+}
+
+// CurrencyMask formats an amount for display — symbol, then thousands
+// grouped with thousands and the fraction after decimal: CurrencyMask("$ ",
+// ".", ",", 2) shows "$ 1.234,50" — and stores it with a plain '.' decimal
+// point ("1234.50"), the form input.Decimal and a float field expect. At
+// most scale fractional digits are kept; a leading '-' is kept.
+func CurrencyMask(symbol, thousands, decimal string, scale int) Formatter {
+	return currencyMask{symbol, thousands, decimal, scale}
+}
+
+type currencyMask struct {
+	symbol, thousands, decimal string
+	scale                      int
+}
+
+func (m currencyMask) Normalize(display string) string {
+	return m.normalize(display, m.pastedPoint(display))
+}
+
+// normalizeTyped reads the mask's own display edited in place: a '.' is
+// always a thousands separator there — "$ 1.23" is "$ 1.234" with its last
+// digit deleted, not 1.23.
+func (m currencyMask) normalizeTyped(display string) string {
+	return m.normalize(display, -1)
+}
+
+// normalize reads display with the byte at dot, if any, as the decimal
+// point.
+func (m currencyMask) normalize(display string, dot int) string {
+	neg, whole, frac, point := false, "", "", false
+	for i := 0; i < len(display); i++ {
+		c := display[i]
+		switch {
+		case c == '-' && whole == "" && !point:
+			neg = true
+		case i == dot:
+			point = true
+		case m.scale > 0 && !point && hasAt(display, i, m.decimal):
+			point = true
+			i += len(m.decimal) - 1
+		case c >= '0' && c <= '9':
+			if !point {
+				whole += string(c)
+			} else if len(frac) < m.scale {
+				frac += string(c)
+			}
+		}
+	}
+	whole = trimLeadingZeros(whole)
+	if whole == "" && point {
+		whole = "0"
+	}
+	out := whole
+	if point {
+		out += "." + frac
+	}
+	if neg {
+		out = "-" + out // a lone "-" too: the user is starting a negative amount
+	}
+	return out
+}
+
+// pastedPoint finds a '.' standing for the decimal point in text that has
+// none of the mask's own — "1234.50" pasted or autofilled, or a stored
+// value: the last '.', followed only by 0..scale digits up to the end.
+// -1 when there is none, and any '.' is a thousands separator.
+func (m currencyMask) pastedPoint(display string) int {
+	if m.scale == 0 || m.decimal == "." || fmt.Index(display, m.decimal) >= 0 {
+		return -1
+	}
+	dot := -1
+	for i := len(display) - 1; i >= 0; i-- {
+		if display[i] == '.' {
+			dot = i
+			break
+		}
+		if display[i] < '0' || display[i] > '9' {
+			return -1
+		}
+	}
+	if dot < 0 || len(display)-dot-1 > m.scale {
+		return -1
+	}
+	return dot
+}
+
+func (m currencyMask) Format(value string) string {
+	if value == "" {
+		return ""
+	}
+	sign := ""
+	if value[0] == '-' {
+		sign, value = "-", value[1:]
+	}
+	whole, frac, point := value, "", false
+	for i := 0; i < len(value); i++ {
+		if value[i] == '.' {
+			whole, frac, point = value[:i], value[i+1:], true
+			break
+		}
+	}
+	out := sign + m.symbol + groupThousands(whole, m.thousands)
+	if point {
+		out += m.decimal + frac
+	}
+	return out
+}
+
+// hasAt reports whether s holds sub at byte offset i.
+func hasAt(s string, i int, sub string) bool {
+	return sub != "" && len(s)-i >= len(sub) && s[i:i+len(sub)] == sub
+}
+
+// groupThousands inserts sep between groups of three digits from the right.
+func groupThousands(digits, sep string) string {
+	if len(digits) <= 3 {
+		return digits
+	}
+	head := len(digits) % 3
+	if head == 0 {
+		head = 3
+	}
+	out := digits[:head]
+	for i := head; i < len(digits); i += 3 {
+		out += sep + digits[i:i+3]
+	}
+	return out
+}
@@ -0,0 +1,49 @@
+package form
+
+import "testing"
+
+func TestMaskCaret(t *testing.T) {
+	cur := CurrencyMask("$ ", ".", ",", 2)
+	cases := []struct {
+		name      string
+		fm        Formatter
+		raw       string
+		caret     int
+		formatted string
+		want      int
+	}{
+		// typed "4" at the end of "$ 123": a separator appears before it
+		{"append grows", cur, "$ 1234", 6, "$ 1.234", 7},
+		// typed "9" right after the "1" of "$ 1.234"
+		{"insert mid", cur, "$ 19.234", 4, "$ 19.234", 4},
+		{"insert shifts group", cur, "$ 91.234", 3, "$ 91.234", 3},
+		{"decimal separator", cur, "$ 1.234,", 8, "$ 1.234,", 8},
+		// deleting the "." of "1.234" re-inserts it: caret stays after "1"
+		{"deleted separator", cur, "$ 1234", 3, "$ 1.234", 3},
+		{"rut", RutMask(), "123456789", 9, "12.345.678-9", 12},
+		// typed "7" after the "3" of "12.345.678-9"
+		{"rut mid", RutMask(), "12.37345.678-9", 5, "1.237.345.678-9", 5},
+		{"pattern", PatternMask("(###) ###-####"), "(555) 1234", 10, "(555) 123-4", 11},
+		// pasted with a '.' point, caret at the end
+		{"pasted point", cur, "1234.50", 7, "$ 1.234,50", 10},
+		// "5" typed after the "2" of "$ 1.234": the '.' stays a separator
+		{"typed mid group", cur, "$ 1.2534", 6, "$ 12.534", 6},
+	}
+	for _, c := range cases {
+		if got := maskCaret(c.fm, c.raw, c.caret, c.formatted); got != c.want {
+			t.Errorf("%s: maskCaret(%q, %d, %q) = %d, want %d", c.name, c.raw, c.caret, c.formatted, got, c.want)
+		}
+	}
+}
+
+func TestCurrencyMask_DeletedDigitIsNotAPoint(t *testing.T) {
+	cur := CurrencyMask("$ ", ".", ",", 2)
+	// backspace at the end of "$ 1.234"
+	if got := normalizeTyped(cur, "$ 1.23"); got != "123" {
+		t.Errorf("normalizeTyped(%q) = %q, want %q", "$ 1.23", got, "123")
+	}
+	// the same text pasted reads the '.' as the point
+	if got := cur.Normalize("1.23"); got != "1.23" {
+		t.Errorf("Normalize(%q) = %q, want %q", "1.23", got, "1.23")
+	}
+}
@@ -0,0 +1,127 @@
+package form
+
+import "github.com/tinywasm/fmt"
+
+// Validation message keys — see Translator. A translation may interpolate
+// {field} (the field name), {label} (its translated label), {value} (the
+// offending value) and {limit} (the bound broken), where the message has
+// them.
+const (
+	KeyErrorRequired   = "error.required"   // {field}
+	KeyErrorMinimum    = "error.minimum"    // {field} {value} {limit}: Constraints.Min
+	KeyErrorMaximum    = "error.maximum"    // {field} {value} {limit}: Constraints.Max
+	KeyErrorMinLength  = "error.minlength"  // {field} {value} {limit}: Permitted.Minimum chars
+	KeyErrorMaxLength  = "error.maxlength"  // {field} {value} {limit}: Permitted.Maximum chars
+	KeyErrorFormat     = "error.format"     // {field} {value}: Constraints.Pattern
+	KeyErrorNotAllowed = "error.notallowed" // {field} {value}: not one of the options
+	KeyErrorExists     = "error.exists"     // {field} {value}: a unique value taken
+	KeyErrorConvert    = "error.convert"    // {field} {value}: see ConvertError
+	KeyErrorFileSize   = "error.file.size"  // {field} {value} {limit}: file name, FileRules.MaxSize
+	KeyErrorFileType   = "error.file.type"  // {field} {value}: the file's MIME type
+)
+
+// Message is a validation error as a Translator sees it: a stable key
+// naming the failure plus the values its text interpolates. Error returns
+// the untranslated text, the same a plain error would carry.
+type Message struct {
+	Key   string // one of the KeyError* constants
+	Field string // field name (Input.FieldName)
+	Value string // the offending value, when the message names one
+	Limit string // the bound broken, when there is one
+	Text  string // untranslated text
+}
+
+func (m *Message) Error() string { return m.Text }
+
+// message builds a Message whose text is words, as fmt.Err joins them.
+func message(key, field, value, limit string, words ...any) *Message {
+	return &Message{Key: key, Field: field, Value: value, Limit: limit, Text: fmt.Err(words...).Error()}
+}
+
+func errRequired(field string) *Message {
+	return message(KeyErrorRequired, field, "", "", "field", field, "is required")
+}
+
+func errNotAllowed(field, value string) *Message {
+	return message(KeyErrorNotAllowed, field, value, "", "Value", value, "NotAllowed", "in", field)
+}
+
+// keyed returns err as a Message when it is one of the shapes the inputs'
+// own Validate and model.Permitted produce for field and value — they only
+// return text, so TestKeyed_PinsUpstreamText pins each shape; anything else
+// comes back as is, translated by its text.
+func keyed(err error, field, value string) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(*Message); ok {
+		return err
+	}
+	text := err.Error()
+	switch text {
+	case errRequired(field).Text:
+		return errRequired(field)
+	case errNotAllowed(field, value).Text:
+		return errNotAllowed(field, value)
+	}
+	for _, k := range []struct{ key, word string }{
+		{KeyErrorMinLength, "minimum"},
+		{KeyErrorMaxLength, "maximum"},
+	} {
+		prefix, suffix := field+" "+k.word+" ", " chars"
+		if fmt.HasPrefix(text, prefix) && fmt.HasSuffix(text, suffix) && len(text) > len(prefix)+len(suffix) {
+			limit := text[len(prefix) : len(text)-len(suffix)]
+			return &Message{Key: k.key, Field: field, Value: value, Limit: limit, Text: text}
+		}
+	}
+	return err
+}
+
+// messageOf returns err as a Message, or nil when it carries no key.
+func messageOf(err error) *Message {
+	switch e := err.(type) {
+	case *Message:
+		return e
+	case *ConvertError:
+		return &Message{Key: KeyErrorConvert, Field: e.Field, Value: e.Value, Text: e.Error()}
+	}
+	return nil
+}
+
+// setError shows err on the field's error span; nil clears it.
+func (fc *fieldComponent) setError(err error) {
+	if err == nil {
+		fc.message = nil
+		fc.err.Set("")
+		return
+	}
+	fc.message = messageOf(err)
+	fc.err.Set(err.Error())
+}
+
+// setError shows err on the i-th field; nil clears it.
+func (f *Form) setError(i int, err error) {
+	f.children[i].(*fieldComponent).setError(err)
+}
+
+// errorText translates the error text on display: by the Message's key,
+// interpolated, when text is the Message last set; otherwise — and for a
+// key the Translator lacks — by the text itself.
+func (fc *fieldComponent) errorText(text string) string {
+	plain := fc.t(text, text)
+	m := fc.message
+	if m == nil || m.Text != text {
+		return plain
+	}
+	out := fc.t(m.Key, "")
+	if out == "" {
+		return plain
+	}
+	label := fc.t("label."+m.Field, fc.labelText())
+	return fmt.Convert(out).
+		Replace("{field}", m.Field).
+		Replace("{label}", label).
+		Replace("{value}", m.Value).
+		Replace("{limit}", m.Limit).
+		String()
+}
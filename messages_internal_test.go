@@ -0,0 +1,55 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/tinywasm/fmt"
+	"github.com/tinywasm/input"
+	"github.com/tinywasm/model"
+)
+
+// TestKeyed_PinsUpstreamText pins keyed to the text input.Base and
+// model.Permitted really produce: they return plain errors, so when either
+// rewords one, translations by key silently fall back to the English text —
+// fail here instead.
+func TestKeyed_PinsUpstreamText(t *testing.T) {
+	b := &input.Base{}
+	b.InitBase("app", "Name", "text")
+	b.SetRequired(true)
+	opts := &input.Base{}
+	opts.InitBase("app", "Name", "select")
+	opts.SetOptions(fmt.KeyValue{Key: "a", Value: "A"})
+
+	cases := []struct {
+		name  string
+		err   error
+		value string
+		want  Message
+	}{
+		{"required", b.Validate(""), "",
+			Message{Key: KeyErrorRequired, Field: "Name"}},
+		{"not allowed", opts.Validate("z"), "z",
+			Message{Key: KeyErrorNotAllowed, Field: "Name", Value: "z"}},
+		{"minimum", model.Permitted{Minimum: 2}.Validate("Name", "a"), "a",
+			Message{Key: KeyErrorMinLength, Field: "Name", Value: "a", Limit: "2"}},
+		{"maximum", model.Permitted{Maximum: 3}.Validate("Name", "abcd"), "abcd",
+			Message{Key: KeyErrorMaxLength, Field: "Name", Value: "abcd", Limit: "3"}},
+	}
+	for _, c := range cases {
+		if c.err == nil {
+			t.Errorf("%s: no error", c.name)
+			continue
+		}
+		m, ok := keyed(c.err, "Name", c.value).(*Message)
+		if !ok {
+			t.Errorf("%s: keyed(%q) is not a Message: the upstream text changed", c.name, c.err.Error())
+			continue
+		}
+		if m.Key != c.want.Key || m.Field != c.want.Field || m.Value != c.want.Value || m.Limit != c.want.Limit {
+			t.Errorf("%s: keyed(%q) = %+v, want %+v", c.name, c.err.Error(), *m, c.want)
+		}
+		if m.Text != c.err.Error() {
+			t.Errorf("%s: Text = %q, want the upstream %q", c.name, m.Text, c.err.Error())
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package form
+
+import "github.com/tinywasm/model"
+
+// Nullable is a null wrapper a Fielder may hand out in Pointers() instead of
+// a **T — sql.NullInt64's shape with methods. Target returns the pointer to
+// the wrapped value (*string, *int64, … — read and written like any other
+// field pointer); IsNull/SetNull are its NULL flag.
+type Nullable interface {
+	Target() any
+	IsNull() bool
+	SetNull(null bool)
+}
+
+// A nullable field — one whose pointer is a **T or a Nullable — is bound as
+// NULL when left empty, not as "" or 0. Per input, nulls[i] records whether
+// an empty value there means NULL: true for every nullable field except one
+// whose record held a non-NULL empty string (kept as "" while untouched).
+// SetNull forces it back on. baseNulls[i] is the NULL state captured with
+// the baseline, so IsDirty sees a NULL turned into "" (or back) as a change.
+
+// nullInner unwraps a nullable field pointer: the pointer to its value (a
+// typed nil for a NULL **T), whether it is NULL, and whether ptr was
+// nullable at all. Any other pointer comes back as is.
+func nullInner(ptr any) (inner any, null, nullable bool) {
+	switch p := ptr.(type) {
+	case **string:
+		return *p, *p == nil, true
+	case **int64:
+		return *p, *p == nil, true
+	case **int:
+		return *p, *p == nil, true
+	case **int32:
+		return *p, *p == nil, true
+	case **uint:
+		return *p, *p == nil, true
+	case **uint32:
+		return *p, *p == nil, true
+	case **uint64:
+		return *p, *p == nil, true
+	case **float64:
+		return *p, *p == nil, true
+	case **float32:
+		return *p, *p == nil, true
+	case **bool:
+		return *p, *p == nil, true
+	case Nullable:
+		return p.Target(), p.IsNull(), true
+	}
+	return ptr, false, false
+}
+
+// setNull stores NULL through a nullable field pointer.
+func setNull(ptr any) {
+	switch p := ptr.(type) {
+	case **string:
+		*p = nil
+	case **int64:
+		*p = nil
+	case **int:
+		*p = nil
+	case **int32:
+		*p = nil
+	case **uint:
+		*p = nil
+	case **uint32:
+		*p = nil
+	case **uint64:
+		*p = nil
+	case **float64:
+		*p = nil
+	case **float32:
+		*p = nil
+	case **bool:
+		*p = nil
+	case Nullable:
+		p.SetNull(true)
+	}
+}
+
+// writeNullable writes a non-NULL value through a nullable field pointer,
+// with writeField's all-or-nothing rule: on a failed conversion the field
+// keeps whatever it held, NULL included.
+func writeNullable(ptr any, ft model.FieldType, val string) bool {
+	switch p := ptr.(type) {
+	case **string:
+		return writeNew(p, ft, val)
+	case **int64:
+		return writeNew(p, ft, val)
+	case **int:
+		return writeNew(p, ft, val)
+	case **int32:
+		return writeNew(p, ft, val)
+	case **uint:
+		return writeNew(p, ft, val)
+	case **uint32:
+		return writeNew(p, ft, val)
+	case **uint64:
+		return writeNew(p, ft, val)
+	case **float64:
+		return writeNew(p, ft, val)
+	case **float32:
+		return writeNew(p, ft, val)
+	case **bool:
+		return writeNew(p, ft, val)
+	case Nullable:
+		if !writeField(p.Target(), ft, []string{val}) {
+			return false
+		}
+		p.SetNull(false)
+	}
+	return true
+}
+
+// writeNew converts val into a fresh *T and only then swaps it in, so a
+// record sharing the old pointer never sees a half-applied write.
+func writeNew[T any](p **T, ft model.FieldType, val string) bool {
+	v := new(T)
+	if !writeField(v, ft, []string{val}) {
+		return false
+	}
+	*p = v
+	return true
+}
+
+// isNull reports whether the i-th input currently stands for NULL.
+func (f *Form) isNull(i int) bool {
+	return f.nulls[i] && f.valueSignals[i].Get() == ""
+}
+
+// IsNull reports whether the named field would be bound as NULL by the next
+// SyncValues: a nullable field left (or made) empty. Always false for a
+// field whose storage is not nullable, or a name that matches no input.
+func (f *Form) IsNull(fieldName string) bool {
+	i := f.inputIndex(fieldName)
+	return i >= 0 && f.isNull(i)
+}
+
+// SetNull empties a nullable field AS NULL — the one way to turn a loaded
+// non-NULL empty string into NULL, which clearing the box cannot express.
+// A no-op for a field that is not nullable. Chainable.
+func (f *Form) SetNull(fieldName string) *Form {
+	i := f.inputIndex(fieldName)
+	if i < 0 || !f.nullable[i] {
+		return f
+	}
+	f.nulls[i] = true
+	f.SetValues(fieldName, "")
+	f.rebase() // the value may already have been ""
+	return f
+}
+
+// captureNull records, for the i-th input just loaded with stored from ptr,
+// whether an empty value there means NULL, and makes that the baseline's
+// NULL state.
+func (f *Form) captureNull(i int, ptr any, stored string) {
+	_, null, nullable := nullInner(ptr)
+	f.nullable[i] = nullable
+	// A non-NULL empty string stays "" until SetNull; everything else that
+	// is nullable reads back as NULL once emptied.
+	f.nulls[i] = nullable && (null || stored != "")
+	f.baseNulls[i] = f.isNull(i)
+}
@@ -0,0 +1,137 @@
+package form
+
+import (
+	"github.com/tinywasm/dom"
+	"github.com/tinywasm/fmt"
+)
+
+// OptionLoader fetches a field's options for the current value of the field
+// it depends on (parent). It calls done exactly once — synchronously or
+// later; an error leaves the field without options and is shown on it.
+type OptionLoader func(parent string, done func(opts []fmt.KeyValue, err error))
+
+// optionLoader is one LoadOptions declaration.
+type optionLoader struct {
+	field     string
+	dependsOn string
+	load      OptionLoader
+	seq       int    // bumps per run; a superseded run's done is ignored
+	last      string // the parent value of the latest run
+	failed    bool   // that run's load errored, so the same parent may retry
+}
+
+// LoadOptions makes field's options come from load, re-run whenever
+// dependsOn changes — committed by the user, set with SetValues, loaded with
+// LoadValues or cleared by a reset — and once right away. Chains cascade:
+// country → region → city is two calls. While a load is in flight the
+// field (select, radio group or datalist) is disabled and aria-busy; an
+// empty parent empties the options without calling load. A current value
+// missing from the new options is cleared, which in turn reloads the fields
+// depending on it. Declare loaders before rendering, so the busy state is
+// wired into the markup. Chainable.
+func (f *Form) LoadOptions(field, dependsOn string, load OptionLoader) *Form {
+	if i := f.inputIndex(field); i >= 0 {
+		if fc := f.children[i].(*fieldComponent); fc.loading == nil {
+			fc.loading = dom.NewBool(false)
+		}
+	}
+	l := &optionLoader{field: field, dependsOn: dependsOn, load: load}
+	f.loaders = append(f.loaders, l)
+	f.runLoader(l)
+	return f
+}
+
+// fieldChanged re-runs every loader depending on the named field whose
+// value differs from the one it last loaded for — a commit fires on every
+// blur, and tabbing through an unchanged parent must not reload.
+func (f *Form) fieldChanged(fieldName string) {
+	p := f.inputIndex(fieldName)
+	if p < 0 {
+		return
+	}
+	parent := f.valueSignals[p].Get()
+	for _, l := range f.loaders {
+		if l.dependsOn == fieldName && (parent != l.last || l.failed) {
+			f.runLoader(l)
+		}
+	}
+}
+
+func (f *Form) runLoader(l *optionLoader) {
+	i, p := f.inputIndex(l.field), f.inputIndex(l.dependsOn)
+	if i < 0 || p < 0 {
+		return
+	}
+	fc := f.children[i].(*fieldComponent)
+	if fc.loading == nil {
+		fc.loading = dom.NewBool(false)
+	}
+	l.seq++
+	seq := l.seq
+	parent := f.valueSignals[p].Get()
+	l.last, l.failed = parent, false
+	if parent == "" {
+		fc.loading.Set(false)
+		f.SetOptions(l.field)
+		return
+	}
+	fc.loading.Set(true)
+	l.load(parent, func(opts []fmt.KeyValue, err error) {
+		if seq != l.seq || f.Inputs == nil {
+			return // superseded by a newer parent value, or the form is gone
+		}
+		fc.loading.Set(false)
+		if err != nil {
+			l.failed = true
+			f.SetOptions(l.field)
+			f.setError(i, err)
+			return
+		}
+		f.SetOptions(l.field, opts...)
+	})
+}
+
+// optionNodes builds the option markup for the field's current options:
+// <option>s for a select or datalist, label+radio pairs for a radio group.
+// Each node carries a key so BindChildren can reconcile it.
+func (fc *fieldComponent) optionNodes() []*dom.Element {
+	opts := fc.Input.GetOptions()
+	nodes := make([]*dom.Element, 0, len(opts))
+	switch fc.Input.HTMLName() {
+	case "radio":
+		for _, opt := range opts {
+			nodes = append(nodes, fc.radioOption(opt))
+		}
+	case "select":
+		for _, opt := range opts {
+			nodes = append(nodes, dom.NewElement("option").
+				Key(opt.Key).
+				Attr("value", opt.Key).
+				BindAttrBoolFunc("selected", func() bool { return fc.value.Get() == opt.Key }).
+				BindTextFunc(func() string { return fc.optionLabel(opt) }))
+		}
+	case "datalist":
+		for _, opt := range opts {
+			nodes = append(nodes, dom.NewElement("option").
+				Key(opt.Key).
+				Attr("value", opt.Key).
+				BindTextFunc(func() string { return fc.optionLabel(opt) }))
+		}
+	}
+	return nodes
+}
+
+// refreshOptions re-renders the option markup after the options changed.
+func (fc *fieldComponent) refreshOptions() {
+	fc.options.Set(fc.optionNodes())
+}
+
+// hasOption reports whether key is one of the field's current options.
+func hasOption(opts []fmt.KeyValue, key string) bool {
+	for _, o := range opts {
+		if o.Key == key {
+			return true
+		}
+	}
+	return false
+}
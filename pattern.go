@@ -0,0 +1,395 @@
+package form
+
+import "github.com/tinywasm/fmt"
+
+// pattern is a compiled Constraints.Pattern. The form ships its own small
+// matcher rather than Go's regexp (RE2) or the browser's RegExp, so the
+// server and the browser agree on every value — and it accepts only the
+// subset where both engines agree too:
+//
+//	literals, .            escaped metacharacters (\. \( \\ …), \t \n \r \f \v \0
+//	[abc] [^a-z] [\d_]     classes, ranges and negation
+//	\d \w \s \D \W \S      ASCII digit/word, JS whitespace (\D \W \S outside classes)
+//	(…) (?:…) a|b          groups and alternation
+//	* + ? {n} {n,} {n,m}   greedy quantifiers, and their lazy form (*? …)
+//	^ $                    anchors (redundant: the whole value must match)
+//
+// Lookarounds, backreferences, named groups, word boundaries and Unicode
+// property escapes are rejected by compilePattern, and so by New.
+type pattern struct {
+	alts [][]*reNode
+}
+
+// reNode is one atom of a pattern with its repetition.
+type reNode struct {
+	kind     byte
+	r        rune
+	ranges   []rune // class: lo, hi pairs
+	negate   bool   // class: [^…]
+	alts     [][]*reNode
+	min, max int // max < 0: unbounded
+	lazy     bool
+}
+
+const (
+	reLit byte = iota
+	reAny
+	reClass
+	reGroup
+	reStart
+	reEnd
+)
+
+// reBudget bounds the steps one match may take; a pattern backtracking
+// past it fails closed rather than hanging the form.
+const reBudget = 100000
+
+var (
+	reDigit = []rune{'0', '9'}
+	reWord  = []rune{'0', '9', 'A', 'Z', '_', '_', 'a', 'z'}
+	reSpace = []rune{'\t', '\r', ' ', ' ', 0xa0, 0xa0, 0x1680, 0x1680, 0x2000, 0x200a,
+		0x2028, 0x2029, 0x202f, 0x202f, 0x205f, 0x205f, 0x3000, 0x3000, 0xfeff, 0xfeff}
+)
+
+// compilePattern parses src, or reports the first construct outside the
+// supported subset.
+func compilePattern(src string) (*pattern, error) {
+	p := &reParser{src: []rune(src)}
+	alts, err := p.alternation()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.src) {
+		return nil, p.fail("unmatched )")
+	}
+	return &pattern{alts: alts}, nil
+}
+
+// match reports whether the whole of val matches, as an HTML pattern
+// attribute would: never a substring.
+func (pt *pattern) match(val string) bool {
+	m := &reMatcher{in: []rune(val)}
+	root := &reNode{kind: reGroup, alts: pt.alts, min: 1, max: 1}
+	return m.seq([]*reNode{root}, 0, func(pos int) bool { return pos == len(m.in) })
+}
+
+type reParser struct {
+	src []rune
+	pos int
+}
+
+func (p *reParser) fail(what string) error {
+	return fmt.Errf("pattern %q: %s at %d", string(p.src), what, p.pos)
+}
+
+func (p *reParser) peek() rune {
+	if p.pos < len(p.src) {
+		return p.src[p.pos]
+	}
+	return -1
+}
+
+func (p *reParser) alternation() ([][]*reNode, error) {
+	var alts [][]*reNode
+	for {
+		seq, err := p.sequence()
+		if err != nil {
+			return nil, err
+		}
+		alts = append(alts, seq)
+		if p.peek() != '|' {
+			return alts, nil
+		}
+		p.pos++
+	}
+}
+
+func (p *reParser) sequence() ([]*reNode, error) {
+	var seq []*reNode
+	for {
+		switch p.peek() {
+		case -1, '|', ')':
+			return seq, nil
+		}
+		n, err := p.atom()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.quantifier(n); err != nil {
+			return nil, err
+		}
+		seq = append(seq, n)
+	}
+}
+
+func (p *reParser) atom() (*reNode, error) {
+	n := &reNode{min: 1, max: 1}
+	c := p.src[p.pos]
+	p.pos++
+	switch c {
+	case '.':
+		n.kind = reAny
+	case '^':
+		n.kind = reStart
+	case '$':
+		n.kind = reEnd
+	case '(':
+		if p.peek() == '?' {
+			if p.pos+1 >= len(p.src) || p.src[p.pos+1] != ':' {
+				return nil, p.fail("lookarounds and named groups are not supported")
+			}
+			p.pos += 2
+		}
+		alts, err := p.alternation()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ')' {
+			return nil, p.fail("missing )")
+		}
+		p.pos++
+		n.kind, n.alts = reGroup, alts
+	case '[':
+		return n, p.class(n)
+	case '\\':
+		return n, p.escape(n, false)
+	case '*', '+', '?', '{':
+		return nil, p.fail("nothing to repeat")
+	case ']', '}':
+		return nil, p.fail("lone " + string(c))
+	default:
+		n.kind, n.r = reLit, c
+	}
+	return n, nil
+}
+
+func (p *reParser) class(n *reNode) error {
+	n.kind = reClass
+	if p.peek() == '^' {
+		n.negate = true
+		p.pos++
+	}
+	for {
+		c := p.peek()
+		switch c {
+		case -1:
+			return p.fail("missing ]")
+		case ']':
+			p.pos++
+			return nil
+		case '[', '(', ')', '{', '}', '/', '|':
+			// the v-flag RegExp browsers compile pattern attributes with
+			// rejects these unescaped in a class
+			return p.fail("unescaped " + string(c) + " in a class")
+		}
+		p.pos++
+		lo := c
+		if c == '\\' {
+			var e reNode
+			if err := p.escape(&e, true); err != nil {
+				return err
+			}
+			if e.kind == reClass {
+				n.ranges = append(n.ranges, e.ranges...)
+				continue
+			}
+			lo = e.r
+		}
+		hi := lo
+		if p.peek() == '-' && p.pos+1 < len(p.src) && p.src[p.pos+1] != ']' {
+			p.pos++
+			hi = p.src[p.pos]
+			p.pos++
+			if hi == '\\' {
+				var e reNode
+				if err := p.escape(&e, true); err != nil || e.kind != reLit {
+					return p.fail("bad range end")
+				}
+				hi = e.r
+			}
+			if hi < lo {
+				return p.fail("range out of order")
+			}
+		}
+		n.ranges = append(n.ranges, lo, hi)
+	}
+}
+
+// escape parses the escape after a backslash into n: a literal, or a
+// shorthand class.
+func (p *reParser) escape(n *reNode, inClass bool) error {
+	c := p.peek()
+	if c == -1 {
+		return p.fail("trailing \\")
+	}
+	p.pos++
+	n.kind = reLit
+	if c == 'D' || c == 'W' || c == 'S' {
+		if inClass {
+			return p.fail("negated shorthand in a class")
+		}
+		n.negate = true
+	}
+	switch c {
+	case 'd', 'D':
+		n.kind, n.ranges = reClass, reDigit
+	case 'w', 'W':
+		n.kind, n.ranges = reClass, reWord
+	case 's', 'S':
+		n.kind, n.ranges = reClass, reSpace
+	case 't':
+		n.r = '\t'
+	case 'n':
+		n.r = '\n'
+	case 'r':
+		n.r = '\r'
+	case 'f':
+		n.r = '\f'
+	case 'v':
+		n.r = '\v'
+	case '0':
+		n.r = 0
+	default:
+		if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '1' && c <= '9') {
+			return p.fail("escape \\" + string(c) + " is not supported")
+		}
+		n.r = c // an escaped metacharacter stands for itself
+	}
+	return nil
+}
+
+func (p *reParser) quantifier(n *reNode) error {
+	switch p.peek() {
+	case '*':
+		n.min, n.max = 0, -1
+	case '+':
+		n.min, n.max = 1, -1
+	case '?':
+		n.min, n.max = 0, 1
+	case '{':
+		return p.braces(n)
+	default:
+		return nil
+	}
+	p.pos++
+	return p.lazy(n)
+}
+
+func (p *reParser) braces(n *reNode) error {
+	p.pos++ // {
+	num := func() (int, bool) {
+		start := p.pos
+		for p.peek() >= '0' && p.peek() <= '9' {
+			p.pos++
+		}
+		v, err := fmt.Convert(string(p.src[start:p.pos])).Int()
+		return v, err == nil && p.pos > start
+	}
+	lo, ok := num()
+	if !ok {
+		return p.fail("bad {}")
+	}
+	hi := lo
+	if p.peek() == ',' {
+		p.pos++
+		if p.peek() == '}' {
+			hi = -1
+		} else if hi, ok = num(); !ok || hi < lo {
+			return p.fail("bad {}")
+		}
+	}
+	if p.peek() != '}' {
+		return p.fail("bad {}")
+	}
+	p.pos++
+	n.min, n.max = lo, hi
+	return p.lazy(n)
+}
+
+func (p *reParser) lazy(n *reNode) error {
+	if n.kind == reStart || n.kind == reEnd {
+		return p.fail("nothing to repeat")
+	}
+	if p.peek() == '?' {
+		n.lazy = true
+		p.pos++
+	}
+	switch p.peek() {
+	case '*', '+', '?', '{':
+		return p.fail("nothing to repeat")
+	}
+	return nil
+}
+
+// reMatcher runs a backtracking match; each step calls k with the
+// position reached and succeeds when k does.
+type reMatcher struct {
+	in    []rune
+	steps int
+}
+
+func (m *reMatcher) seq(nodes []*reNode, pos int, k func(int) bool) bool {
+	if len(nodes) == 0 {
+		return k(pos)
+	}
+	rest := nodes[1:]
+	return m.repeat(nodes[0], 0, pos, func(p int) bool { return m.seq(rest, p, k) })
+}
+
+func (m *reMatcher) repeat(n *reNode, count, pos int, k func(int) bool) bool {
+	if m.steps++; m.steps > reBudget {
+		return false
+	}
+	more := func() bool {
+		if n.max >= 0 && count >= n.max {
+			return false
+		}
+		return m.one(n, pos, func(p int) bool {
+			if p == pos && count >= n.min {
+				return false // an empty iteration adds nothing
+			}
+			return m.repeat(n, count+1, p, k)
+		})
+	}
+	if count < n.min {
+		return more()
+	}
+	if n.lazy {
+		return k(pos) || more()
+	}
+	return more() || k(pos)
+}
+
+func (m *reMatcher) one(n *reNode, pos int, k func(int) bool) bool {
+	switch n.kind {
+	case reStart:
+		return pos == 0 && k(pos)
+	case reEnd:
+		return pos == len(m.in) && k(pos)
+	case reGroup:
+		for _, alt := range n.alts {
+			if m.seq(alt, pos, k) {
+				return true
+			}
+		}
+		return false
+	}
+	if pos >= len(m.in) {
+		return false
+	}
+	c := m.in[pos]
+	switch n.kind {
+	case reLit:
+		return c == n.r && k(pos+1)
+	case reAny:
+		return c != '\n' && c != '\r' && c != 0x2028 && c != 0x2029 && k(pos+1)
+	}
+	in := false
+	for i := 0; i+1 < len(n.ranges); i += 2 {
+		if c >= n.ranges[i] && c <= n.ranges[i+1] {
+			in = true
+			break
+		}
+	}
+	return in != n.negate && k(pos+1)
+}
@@ -0,0 +1,67 @@
+package form
+
+import "testing"
+
+func TestPattern_Matches(t *testing.T) {
+	cases := []struct {
+		pattern, val string
+		want         bool
+	}{
+		{"[A-Z]{2}[0-9]{3}", "CD456", true},
+		{"[A-Z]{2}[0-9]{3}", "xCD456", false}, // the whole value, never a substring
+		{"[A-Z]{2}[0-9]{3}", "CD4567", false},
+		{`\d{1,3}(\.\d{3})*`, "1.234.567", true},
+		{`\d{1,3}(\.\d{3})*`, "1.23", false},
+		{"a|bc", "bc", true},
+		{"a|bc", "abc", false},
+		{"(?:ab)+c?", "ababc", true},
+		{`[^\s@]+@[^\s@]+\.[a-z]{2,}`, "ana@mail.cl", true},
+		{`[^\s@]+@[^\s@]+\.[a-z]{2,}`, "ana @mail.cl", false},
+		{`\w+\-\w+`, "a_1-b", true},
+		{"ñ.é", "ñxé", true}, // runes, not bytes
+		{"a.*?b", "axxb", true},
+		{"(a*)*b", "aaaaaaaaaaaaaaaaaaaaaaaac", false}, // backtracks within budget
+		{"", "", true},
+		{"", "x", false},
+	}
+	for _, c := range cases {
+		pt, err := compilePattern(c.pattern)
+		if err != nil {
+			t.Errorf("compile %q: %v", c.pattern, err)
+			continue
+		}
+		if got := pt.match(c.val); got != c.want {
+			t.Errorf("%q ~ %q = %v, want %v", c.pattern, c.val, got, c.want)
+		}
+	}
+}
+
+// TestPattern_RejectsEngineSpecific covers patterns the browser's RegExp
+// and Go's RE2 read differently — or only one of them accepts — so the
+// server and the browser would disagree on a value.
+func TestPattern_RejectsEngineSpecific(t *testing.T) {
+	for _, p := range []string{
+		`(?=.*\d).{8,}`, // lookahead: JS only
+		`(?<!x)y`,       // lookbehind: JS only
+		`(a)\1`,         // backreference: JS only
+		`(?P<n>a)`,      // named group, RE2 spelling
+		`\bword\b`,      // word boundary
+		`\p{L}+`,        // Unicode property: needs the u flag in JS
+		`[a-z(]`,        // unescaped ( in a class: the v-flag RegExp rejects it
+		`(?i)abc`,       // inline flags: RE2 only
+		`a{2`,           // a literal brace in RE2, an error in JS
+		`*a`, `a)`, `[a`, `a\`,
+	} {
+		if _, err := compilePattern(p); err == nil {
+			t.Errorf("%q should be rejected", p)
+		}
+	}
+}
+
+func TestPattern_NewRejectsUnsupported(t *testing.T) {
+	_, err := New("app", &triggerRecord{}, &testIDGen{},
+		WithConstraints("Name", Constraints{Pattern: `(?=.*\d).{8,}`}))
+	if err == nil {
+		t.Error("New should reject a pattern outside the supported subset")
+	}
+}
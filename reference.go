@@ -0,0 +1,172 @@
+package form
+
+import (
+	"github.com/tinywasm/dom"
+	"github.com/tinywasm/fmt"
+	"github.com/tinywasm/input"
+	"github.com/tinywasm/model"
+	"github.com/tinywasm/widget"
+)
+
+// Lookup is what a reference field knows about the model it points at:
+// Search finds records for the text typed (Key the id, Value the label, as
+// a SearchProvider), Label resolves a stored id to its label. Both call done
+// once, synchronously or later.
+type Lookup interface {
+	Search(query string, done func(results []fmt.KeyValue))
+	Label(id string, done func(label string, err error))
+}
+
+// Creator is implemented by a Lookup whose records can be created from the
+// referencing form: NewRecord returns a blank record of the referenced model
+// for the nested form, Create persists the submitted one and reports its id
+// and label.
+type Creator interface {
+	NewRecord() model.Fielder
+	Create(data model.Fielder, done func(id, label string, err error))
+}
+
+// Reference creates a field holding the id of a related record — a
+// customer_id — picked by searching the related model. It is a Combobox over
+// lookup.Search that also resolves the stored id's label through
+// lookup.Label, so a loaded record shows "Acme Corp", not "17". When lookup
+// is also a Creator the field offers a "New" button opening a nested form for
+// the referenced model (see CreateReference). The id may be bound to a
+// string or an integer field.
+func Reference(lookup Lookup) input.Input {
+	c := &combobox{search: lookup.Search, resolver: lookup.Label}
+	if cr, ok := lookup.(Creator); ok {
+		c.creator = cr
+	}
+	c.InitBase("", "", "combobox")
+	return c
+}
+
+// Reference button message keys — see Translator.
+const (
+	KeyReferenceCreate = "reference.create"
+	KeyReferenceCancel = "reference.cancel"
+)
+
+// resolveLabel has the i-th input, when it is a reference, look up the label
+// of its current value.
+func (f *Form) resolveLabel(i int) {
+	if c, ok := f.Inputs[i].(*combobox); ok {
+		c.resolve(f.valueSignals[i].Get())
+	}
+}
+
+// CreateReference opens the "create new" form of the named reference field
+// — a nested form for the referenced model (its Lookup's Creator.NewRecord),
+// rendered below the field with the parent's theme, layout, translator and
+// language. Submitting it calls Creator.Create; on success the new record is
+// picked in the field and the nested form closes, on error it stays open
+// with the submission failed. Returns the nested form (the one already open,
+// if any), so a host can prefill or inspect it. The field's "New" button
+// calls this.
+func (f *Form) CreateReference(fieldName string) (*Form, error) {
+	i := f.inputIndex(fieldName)
+	if i < 0 {
+		return nil, fmt.Errf("form.CreateReference: no field %q", fieldName)
+	}
+	c, ok := f.Inputs[i].(*combobox)
+	if !ok || c.creator == nil {
+		return nil, fmt.Errf("form.CreateReference: %q is not a reference field whose Lookup is a Creator", fieldName)
+	}
+	fc := f.children[i].(*fieldComponent)
+	if fc.sub != nil {
+		return fc.sub, nil
+	}
+	sub, err := New(fc.createPanelID(), c.creator.NewRecord(), f.idGen,
+		WithTheme(f.theme), WithLayout(f.layout), WithTranslator(f.translator), WithFocusTrap())
+	if err != nil {
+		return nil, fmt.Errf("form.CreateReference: %v", err)
+	}
+	sub.nested = true
+	sub.lang = f.lang // follows the parent's SetLang
+	cancelID := fc.createPanelID() + ".cancel"
+	sub.trapLast = cancelID // outside sub's element, so it forwards its keys
+	sub.OnSubmit(func(data model.Fielder, done func(error)) {
+		c.creator.Create(data, func(id, label string, err error) {
+			done(err)
+			if err != nil || fc.sub != sub {
+				return // failed, or cancelled meanwhile
+			}
+			c.remember(fmt.KeyValue{Key: id, Value: label})
+			f.CancelReference(fieldName)
+			f.SetValues(fieldName, id)
+			fc.check(id, true)
+			f.changed = fieldName
+			if f.onFieldChange != nil {
+				f.onFieldChange() // a pick like any other: auto-save sees it
+			}
+		})
+	})
+	fc.sub = sub
+	fc.nested.Set([]*dom.Element{sub.Render(), dom.NewElement("button").
+		Attr("type", "button").
+		ID(cancelID).
+		Class(joinClass(widget.NameField.Class("cancel").String(), f.theme.Submit)).
+		Text(f.Translate(KeyReferenceCancel, "Cancel")).
+		On("keydown", func(e dom.Event) { sub.trapTab(e) }).
+		On("click", func(dom.Event) { f.CancelReference(fieldName) })})
+	return sub, nil
+}
+
+// CancelReference closes the named field's "create new" form, if open, and
+// disposes it; a Create still in flight is then ignored. Chainable.
+func (f *Form) CancelReference(fieldName string) *Form {
+	if i := f.inputIndex(fieldName); i >= 0 {
+		f.children[i].(*fieldComponent).closeCreate()
+	}
+	return f
+}
+
+// closeReferences closes every open "create new" form — the record they
+// would have been attached to is gone (reset, LoadValues, Dispose).
+func (f *Form) closeReferences() {
+	for _, child := range f.children {
+		child.(*fieldComponent).closeCreate()
+	}
+}
+
+func (fc *fieldComponent) closeCreate() {
+	if fc.sub == nil {
+		return
+	}
+	fc.sub.Dispose()
+	fc.sub = nil
+	fc.nested.Set(nil)
+}
+
+func (fc *fieldComponent) createPanelID() string { return fc.Input.GetID() + ".create" }
+
+// renderCreate builds a reference field's "New" button and the panel its
+// nested form opens in.
+func (fc *fieldComponent) renderCreate() []*dom.Element {
+	if fc.nested == nil {
+		fc.nested = dom.NewNodes()
+	}
+	panelID := fc.createPanelID()
+	btn := dom.NewElement("button").
+		Attr("type", "button").
+		Class(widget.NameField.Class("create").String()).
+		Attr("aria-controls", panelID).
+		BindAttrFunc("aria-expanded", func() string {
+			if len(fc.nested.Get()) > 0 {
+				return "true"
+			}
+			return "false"
+		}).
+		BindAttrBoolFunc("disabled", fc.isDisabledOrLocked).
+		BindTextFunc(func() string { return fc.t(KeyReferenceCreate, "New") }).
+		On("click", func(dom.Event) {
+			if fc.create != nil {
+				fc.create()
+			}
+		})
+	panel := dom.NewElement("div").
+		ID(panelID).
+		Class(widget.NameField.Class("nested").String())
+	return []*dom.Element{btn, bindNodes(panel, fc.nested)}
+}
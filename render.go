@@ -18,7 +18,17 @@ func (f *Form) String() string {
 
 // Render returns a reactive dom.Element tree for the form.
 func (f *Form) Render() *dom.Element {
-	el := dom.NewElement("form").ID(f.GetID())
+	// A reference's "create new" form renders inside its parent's <form>,
+	// where HTML forbids another <form>: it becomes a div with the form role
+	// whose submit button is a plain button.
+	tag := "form"
+	if f.nested {
+		tag = "div"
+	}
+	el := dom.NewElement(tag).ID(f.GetID())
+	if f.nested {
+		el.Attr("role", "form")
+	}
 
 	if f.class != "" {
 		el.Class(f.class)
@@ -37,10 +47,13 @@ func (f *Form) Render() *dom.Element {
 	if !f.noSubmit {
 		btn := dom.NewElement("button").
 			Attr("type", "submit").
-			Class(widget.NameField.Class(widget.PartSubmit).String()).
+			Class(joinClass(widget.NameField.Class(widget.PartSubmit).String(), f.theme.Submit)).
 			ID(f.id + ".submit")
 
 		btn.BindAttrBool("disabled", f.submitting)
+		if f.nested {
+			btn.Attr("type", "button").On("click", func(dom.Event) { f.Submit() })
+		}
 
 		btn.BindTextFunc(func() string {
 			if f.submitting.Get() {
@@ -48,7 +61,7 @@ func (f *Form) Render() *dom.Element {
 				if label == "" {
 					label = f.resolveSubmitLabel() + "..."
 				}
-				return label
+				return f.Translate(KeySubmitLoading, label)
 			}
 			return f.resolveSubmitLabel()
 		})
@@ -61,11 +74,40 @@ func (f *Form) Render() *dom.Element {
 		// squared-off stack. Sharing the wrapper aligns it by construction
 		// rather than by a margin tuned to match fieldset's padding.
 		el.Child(dom.NewElement("div").
-			Class(widget.NameField.Root().String()).
+			Class(joinClass(widget.NameField.Root().String(), f.theme.Field)).
 			Child(btn))
 	}
 
+	// Key bindings (see WithKeyBinding) and the focus trap (see
+	// WithFocusTrap): one listener for every field. A key a control handled
+	// is its own. A nested form's keys stop at it, handled or not — an
+	// Escape it leaves alone must not revert the parent — except a Tab,
+	// which the parent's focus trap may need.
+	el.On("keydown", func(e dom.Event) {
+		if f.nested && eventKey(e) != "Tab" {
+			e.StopPropagation()
+		}
+		if eventPrevented(e) || f.trapTab(e) {
+			return
+		}
+		if f.handleKey(eventChord(e), e.TargetID()) {
+			e.PreventDefault()
+			e.StopPropagation()
+			return
+		}
+		// Enter would implicitly submit the enclosing <form>: submit a
+		// nested one instead (a textarea keeps its newline).
+		if f.nested && eventKey(e) == "Enter" && !f.isTextarea(e.TargetID()) {
+			e.PreventDefault()
+			e.StopPropagation()
+			f.Submit()
+		}
+	})
+
 	// Bind submit event
+	if f.nested {
+		return el
+	}
 	el.On("submit", func(e dom.Event) {
 		e.PreventDefault()
 		f.Submit()
@@ -73,3 +115,13 @@ func (f *Form) Render() *dom.Element {
 
 	return el
 }
+
+// isTextarea reports whether id is the id of one of the form's textareas.
+func (f *Form) isTextarea(id string) bool {
+	for _, inp := range f.Inputs {
+		if inp.GetID() == id && inp.HTMLName() == "textarea" {
+			return true
+		}
+	}
+	return false
+}
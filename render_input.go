@@ -7,28 +7,102 @@ import (
 	"github.com/tinywasm/widget"
 )
 
-// labelChars is the label's budget, calibrated against the --chip-width the
-// form skin gives the chip. Truncate counts the three-byte ellipsis inside this
-// number, so the visible text is labelChars-3 at most.
-//
-// The count is BYTES, not runes: an accented character costs two, and a cut can
-// land mid-character. Field names are identifiers in practice, so this is
-// tolerable here; a title with accents long enough to trip it belongs in the
-// input's title, not its label.
+// labelChars is the default label budget, calibrated against the --chip-width
+// the form skin gives the chip. truncateLabel counts the "..." ellipsis inside
+// this number, so the visible text is labelChars-3 characters at most. A
+// theme overrides it via Theme.LabelChars.
 const labelChars = 14
 
+// truncateLabel cuts s to budget characters — runes, not bytes, so
+// "Teléfono móvil" costs 14 like any other 14-letter label and a cut never
+// lands mid-character. The ellipsis counts inside the budget; a budget too
+// small to hold it just cuts. budget <= 0 disables truncation.
+func truncateLabel(s string, budget int) string {
+	if budget <= 0 {
+		return s
+	}
+	n := 0
+	for range s {
+		n++
+	}
+	if n <= budget {
+		return s
+	}
+	keep, ellipsis := budget-3, "..."
+	if keep <= 0 {
+		keep, ellipsis = budget, ""
+	}
+	i := 0
+	for pos := range s {
+		if i == keep {
+			return s[:pos] + ellipsis
+		}
+		i++
+	}
+	return s
+}
+
 // fieldComponent wraps an input.Input to implement dom.Component.
 type fieldComponent struct {
 	input.Input
 	value *dom.SignalString
 	err   *dom.SignalString
+	// message is the keyed form of the error last set (see setError), nil
+	// when it has no key.
+	message *Message
 	// locked mirrors the owning Form's whole-form read-only gate (Form.SetLocked).
 	// Shared across every field, so toggling it re-locks/unlocks the entire form.
 	locked *dom.SignalBool
+	// theme points at the owning Form's theme (see WithTheme); nil for the
+	// standalone RenderInput helper, which renders the bare anatomy.
+	theme *Theme
+	// layout assembles label, control(s) and error span into the field's
+	// markup (see WithLayout); nil means DefaultLayout.
+	layout FieldLayout
+	// tr is the owning Form's Translate (see WithTranslator); nil for the
+	// standalone RenderInput helper, which renders untranslated text.
+	tr func(key, fallback string) string
 	// onCommit fires when the user finishes editing this field (blur for
 	// text/textarea/datalist, change for select/radio) — the auto-save hook set
 	// via Form.OnFieldChange. Nil when the form has none registered.
 	onCommit func()
+	// options holds the rendered option markup (see optionNodes), re-set
+	// whenever the options change so a select/radio/datalist re-renders.
+	options *dom.SignalNodes
+	// loading is true while a LoadOptions loader runs for this field.
+	loading *dom.SignalBool
+	// selectFile hands a file picked in a file field to the owning Form
+	// (Form.SelectFile). Nil for the standalone RenderInput helper.
+	selectFile func(FileInfo)
+	// create opens a reference field's "create new" form (Form.CreateReference);
+	// sub is that nested form while open and nested its rendered panel.
+	create func()
+	sub    *Form
+	nested *dom.SignalNodes
+	// formatter masks a text control (see Formatter); nil shows the value
+	// as is.
+	formatter Formatter
+	// computed marks a field whose value is derived (Form.Compute): it
+	// renders read-only.
+	computed bool
+	// rules are the field's schema rules (see Constraints), checked live
+	// after the input's own Validate; nil for the standalone helper.
+	rules *fieldRules
+	// trigger is when the field validates live (see Trigger); touched,
+	// visited and active are the owning Form's signals for it, nil for the
+	// standalone helper.
+	trigger Trigger
+	touched *dom.SignalBool
+	visited *dom.SignalBool
+	active  *dom.SignalBool
+	// delay debounces live validation (see WithDebounce) on timer;
+	// pendingCheck and pendingInput cancel the scheduled validation and
+	// OnFieldInput call. onInput is the owning Form's OnFieldInput hook.
+	delay        int
+	timer        Timer
+	pendingCheck func()
+	pendingInput func()
+	onInput      func()
 }
 
 // isDisabledOrLocked combines the field's own static disabled flag with the
@@ -37,6 +111,11 @@ func (fc *fieldComponent) isDisabledOrLocked() bool {
 	return fc.Input.IsDisabled() || (fc.locked != nil && fc.locked.Get())
 }
 
+// isBusy reports whether the field's options are loading (see LoadOptions).
+func (fc *fieldComponent) isBusy() bool {
+	return fc.loading != nil && fc.loading.Get()
+}
+
 func (fc *fieldComponent) String() string {
 	return fc.Render().String()
 }
@@ -62,18 +141,63 @@ func (fc *fieldComponent) Children() []dom.Component {
 // validation: the widget must call onInput with the new value on user input —
 // the form updates the value signal and runs live validation. The value
 // signal carries the initial value and programmatic updates (SetValues).
+// The form sets aria-describedby/aria-invalid/aria-required on the element
+// RenderInput returns, so return the control itself, or a wrapper that
+// carries the widget's ARIA role.
 type Renderer interface {
 	RenderInput(value *dom.SignalString, onInput func(string)) *dom.Element
 }
 
+// th returns the owning form's theme, or the empty theme when standalone.
+func (fc *fieldComponent) th() Theme {
+	if fc.theme == nil {
+		return Theme{}
+	}
+	return *fc.theme
+}
+
 func (fc *fieldComponent) validate(val string) {
-	if err := fc.Input.Validate(val); err != nil {
-		fc.err.Set(err.Error())
-	} else {
-		fc.err.Set("")
+	fc.stop(&fc.pendingCheck) // superseded
+	err := keyed(fc.Input.Validate(val), fc.Input.FieldName(), val)
+	if err == nil && fc.rules != nil {
+		err = fc.rules.check(val)
+	}
+	fc.setError(err)
+}
+
+// t translates key via the owning form, falling back to the given text.
+// Called inside Bind*Func closures so a language switch re-renders it.
+func (fc *fieldComponent) t(key, fallback string) string {
+	if fc.tr == nil {
+		return fallback
+	}
+	return fc.tr(key, fallback)
+}
+
+// optionLabel is the (translated) display text of one option.
+func (fc *fieldComponent) optionLabel(opt fmt.KeyValue) string {
+	return fc.t("option."+fc.Input.FieldName()+"."+opt.Key, opt.Value)
+}
+
+// labelBudget resolves the theme's LabelChars: zero means the package
+// default, NoTruncation (or any negative) means no limit.
+func (fc *fieldComponent) labelBudget() int {
+	switch n := fc.th().LabelChars; {
+	case n == 0:
+		return labelChars
+	case n < 0:
+		return 0
+	default:
+		return n
 	}
 }
 
+// labelID is the id of the field's <label>, referenced by aria-labelledby
+// where `for` can't express the relation (radio groups).
+func (fc *fieldComponent) labelID() string {
+	return fc.Input.GetID() + ".label"
+}
+
 // labelText picks the human label for the field's chip: the title first, then
 // the placeholder, then the raw field name as a last resort.
 func (fc *fieldComponent) labelText() string {
@@ -87,53 +211,100 @@ func (fc *fieldComponent) labelText() string {
 }
 
 func (fc *fieldComponent) Render() *dom.Element {
-	container := dom.NewElement("div").
-		Class(widget.NameField.Root().String()).
-		BindStateFunc(widget.Invalid, func() bool { return fc.err.Get() != "" }).
-		BindStateFunc(widget.Locked, fc.isDisabledOrLocked)
+	if fc.options == nil {
+		fc.options = dom.NewNodes()
+	}
+	parts := FieldParts{
+		Class:   joinClass(widget.NameField.Root().String(), fc.th().Field),
+		Invalid: func() bool { return fc.err.Get() != "" },
+		Locked:  fc.isDisabledOrLocked,
+	}
 
 	// Field label. Rendered structurally for every titled field so a global form
 	// skin (e.g. components/fieldset) can present it as a chip/legend; `for` ties
 	// it to the input for click-to-focus. Form ships no styling for it — the look
 	// is the consumer's skin.
 	if lbl := fc.labelText(); lbl != "" {
-		container.Child(dom.NewElement("label").
+		key := "label." + fc.Input.FieldName()
+		parts.Label = dom.NewElement("label").
+			ID(fc.labelID()).
 			Attr("for", fc.Input.GetID()).
-			Class(widget.NameField.Class(widget.PartLabel).String()).
-			Attr("title", lbl). // the untruncated text stays reachable
-			Text(fmt.Convert(lbl).Truncate(labelChars).String()))
+			Class(joinClass(widget.NameField.Class(widget.PartLabel).String(), fc.th().Label)).
+			// the untruncated text stays reachable
+			BindAttrFunc("title", func() string { return fc.t(key, lbl) }).
+			BindTextFunc(func() string {
+				return truncateLabel(fc.t(key, lbl), fc.labelBudget())
+			})
 	}
 
-	if r, ok := fc.Input.(Renderer); ok {
-		container.Child(r.RenderInput(fc.value, func(v string) {
+	if fc.computed {
+		parts.Controls = append(parts.Controls, fc.renderComputed())
+	} else if c, ok := fc.Input.(*combobox); ok {
+		// The form's own widget gets the field's context: a pick or a
+		// cleared box is a commit, like a select's change.
+		parts.Controls = append(parts.Controls, c.render(fc.value, func(v string) {
+			fc.value.Set(v)
+			fc.check(v, true)
+			if fc.onCommit != nil {
+				fc.onCommit()
+			}
+		}, fc))
+		if c.creator != nil {
+			parts.Controls = append(parts.Controls, fc.renderCreate()...)
+		}
+	} else if r, ok := fc.Input.(Renderer); ok {
+		// The form cannot reach inside a custom widget, so the ARIA wiring
+		// lands on the element it returns — the control itself, or a wrapper
+		// carrying its own ARIA role (see Renderer).
+		parts.Controls = append(parts.Controls, applyAria(r.RenderInput(fc.value, func(v string) {
 			fc.value.Set(v)
-			fc.validate(v)
-		}))
+			fc.edited(v)
+		}), fc))
 	} else {
 		htmlName := fc.Input.HTMLName()
 		switch htmlName {
 		case "radio":
-			fc.renderRadio(container)
+			parts.Controls = append(parts.Controls, fc.renderRadio())
 		case "select":
-			fc.renderSelect(container)
+			parts.Controls = append(parts.Controls, fc.renderSelect())
 		case "datalist":
-			fc.renderDatalist(container)
+			in, list := fc.renderDatalist()
+			parts.Controls = append(parts.Controls, in, list)
+		case "file":
+			if fi, ok := fc.Input.(*fileInput); ok {
+				parts.Controls = append(parts.Controls, fc.renderFile(fi)...)
+				break
+			}
+			parts.Controls = append(parts.Controls, fc.renderInput())
 		default:
-			fc.renderInput(container)
+			parts.Controls = append(parts.Controls, fc.renderInput())
 		}
 	}
 
-	errSpan := dom.NewElement("span").
+	parts.Error = dom.NewElement("span").
 		ID(fc.Input.ErrorID()).
-		Class(widget.NameField.Class(widget.PartError).String()).
+		Class(joinClass(widget.NameField.Class(widget.PartError).String(), fc.th().Error)).
 		Attr("aria-live", "polite").
-		BindText(fc.err)
+		BindTextFunc(func() string {
+			if msg := fc.err.Get(); msg != "" {
+				return fc.errorText(msg)
+			}
+			return ""
+		})
 
-	container.Child(errSpan)
-	return container
+	layout := fc.layout
+	if layout == nil {
+		layout = DefaultLayout{}
+	}
+	root := layout.LayoutField(parts)
+	if fc.touched != nil {
+		root.On("focusin", func(dom.Event) { fc.enter() })
+		root.On("focusout", func(dom.Event) { fc.leave() })
+	}
+	return root
 }
 
-func (fc *fieldComponent) renderInput(container *dom.Element) {
+func (fc *fieldComponent) renderInput() *dom.Element {
 	tag := "input"
 	htmlName := fc.Input.HTMLName()
 	if htmlName == "textarea" {
@@ -142,13 +313,26 @@ func (fc *fieldComponent) renderInput(container *dom.Element) {
 
 	el := dom.NewElement(tag).
 		ID(fc.Input.GetID()).
-		Class(widget.NameField.Class(widget.PartInput).String()).
+		Class(joinClass(widget.NameField.Class(widget.PartInput).String(), fc.th().Input)).
 		Attr("name", fc.Input.FieldName())
 
 	if tag == "input" {
 		el.Attr("type", htmlName)
 	}
 
+	if fc.formatter != nil && tag == "input" {
+		if htmlName == "number" {
+			// a number control cannot show "$ 1.234,50"
+			el.Attr("type", "text").Attr("inputmode", "decimal")
+		}
+		fc.bindMasked(el)
+		if fc.onCommit != nil {
+			el.On("blur", func(dom.Event) { fc.onCommit() })
+		}
+		applyCommonAttrs(el, fc)
+		return el
+	}
+
 	// Initial value for SSR
 	val := fc.value.Get()
 	if val != "" {
@@ -164,97 +348,103 @@ func (fc *fieldComponent) renderInput(container *dom.Element) {
 	el.On("input", func(e dom.Event) {
 		val := e.TargetValue()
 		fc.value.Set(val)
-		fc.validate(val)
+		fc.edited(val)
 	})
 	if fc.onCommit != nil {
 		el.On("blur", func(dom.Event) { fc.onCommit() })
 	}
 
 	applyCommonAttrs(el, fc)
-	container.Child(el)
+	return el
 }
 
-func (fc *fieldComponent) renderSelect(container *dom.Element) {
+func (fc *fieldComponent) renderSelect() *dom.Element {
 	el := dom.NewElement("select").
 		ID(fc.Input.HandlerName()).
-		Class(widget.NameField.Class(widget.PartInput).String()).
+		Class(joinClass(widget.NameField.Class(widget.PartInput).String(), fc.th().Input)).
 		Attr("name", fc.Input.FieldName())
 
 	if fc.Input.IsRequired() {
 		el.Attr("required", "")
 	}
-	el.BindAttrBoolFunc("disabled", fc.isDisabledOrLocked)
-
-	val := fc.value.Get()
+	el.BindAttrBoolFunc("disabled", func() bool { return fc.isDisabledOrLocked() || fc.isBusy() })
+	applyBusy(el, fc)
+	applyAria(el, fc)
 
 	// Two-way binding for select
 	el.Bind(fc.value)
 	el.On("change", func(e dom.Event) {
 		val := e.TargetValue()
 		fc.value.Set(val)
-		fc.validate(val)
+		fc.check(val, true)
 		if fc.onCommit != nil {
 			fc.onCommit()
 		}
 	})
 
-	for _, opt := range fc.Input.GetOptions() {
-		option := dom.NewElement("option").Attr("value", opt.Key).Text(opt.Value)
-		if val != "" && opt.Key == val {
-			option.Attr("selected", "")
-		}
-		el.Child(option)
+	fc.refreshOptions()
+	return bindNodes(el, fc.options)
+}
+
+func (fc *fieldComponent) renderRadio() *dom.Element {
+	// The group, not each radio, is the field: it carries the role, the
+	// group label (the field's <label> can't use `for` — there is no single
+	// control to point at) and the ARIA state.
+	group := dom.NewElement("div").
+		Class(widget.NameField.Class(widget.PartRadioGroup).String()).
+		Attr("role", "radiogroup")
+	if fc.labelText() != "" {
+		group.Attr("aria-labelledby", fc.labelID())
 	}
-	container.Child(el)
+	applyBusy(group, fc)
+	applyAria(group, fc)
+	fc.refreshOptions()
+	return bindNodes(group, fc.options)
 }
 
-func (fc *fieldComponent) renderRadio(container *dom.Element) {
-	group := dom.NewElement("div").Class(widget.NameField.Class(widget.PartRadioGroup).String())
-	val := fc.value.Get()
-	for _, opt := range fc.Input.GetOptions() {
-		optID := fc.Input.HandlerName() + "." + opt.Key
-		label := dom.NewElement("label")
-
-		radio := dom.NewElement("input").
-			Attr("type", "radio").
-			ID(optID).
-			Attr("name", fc.Input.FieldName()).
-			Attr("value", opt.Key)
-
-		if val != "" && opt.Key == val {
-			radio.Attr("checked", "")
-		}
+// radioOption builds one label+radio pair of a radio group.
+func (fc *fieldComponent) radioOption(opt fmt.KeyValue) *dom.Element {
+	optID := fc.Input.HandlerName() + "." + opt.Key
+	label := dom.NewElement("label").Key(optID)
 
-		// Reactive checked state
-		radio.BindAttrBoolFunc("checked", func() bool {
-			return fc.value.Get() == opt.Key
-		})
-		radio.BindAttrBoolFunc("disabled", fc.isDisabledOrLocked)
-
-		radio.On("change", func(e dom.Event) {
-			if e.TargetChecked() {
-				fc.value.Set(opt.Key)
-				fc.validate(opt.Key)
-				if fc.onCommit != nil {
-					fc.onCommit()
-				}
-			}
-		})
+	radio := dom.NewElement("input").
+		Attr("type", "radio").
+		ID(optID).
+		Attr("name", fc.Input.FieldName()).
+		Attr("value", opt.Key)
 
-		label.Child(radio)
-		label.Child(dom.NewElement("span").Text(opt.Value))
-		group.Child(label)
+	if val := fc.value.Get(); val != "" && opt.Key == val {
+		radio.Attr("checked", "")
 	}
-	container.Child(group)
+
+	// Reactive checked state
+	radio.BindAttrBoolFunc("checked", func() bool {
+		return fc.value.Get() == opt.Key
+	})
+	radio.BindAttrBoolFunc("disabled", func() bool { return fc.isDisabledOrLocked() || fc.isBusy() })
+
+	radio.On("change", func(e dom.Event) {
+		if e.TargetChecked() {
+			fc.value.Set(opt.Key)
+			fc.check(opt.Key, true)
+			if fc.onCommit != nil {
+				fc.onCommit()
+			}
+		}
+	})
+
+	label.Child(radio)
+	label.Child(dom.NewElement("span").BindTextFunc(func() string { return fc.optionLabel(opt) }))
+	return label
 }
 
-func (fc *fieldComponent) renderDatalist(container *dom.Element) {
+func (fc *fieldComponent) renderDatalist() (*dom.Element, *dom.Element) {
 	listID := fc.Input.GetID() + "-list"
 
 	el := dom.NewElement("input").
 		Attr("type", "text").
 		ID(fc.Input.GetID()).
-		Class(widget.NameField.Class(widget.PartInput).String()).
+		Class(joinClass(widget.NameField.Class(widget.PartInput).String(), fc.th().Input)).
 		Attr("name", fc.Input.FieldName()).
 		Attr("list", listID)
 
@@ -263,30 +453,37 @@ func (fc *fieldComponent) renderDatalist(container *dom.Element) {
 	el.On("input", func(e dom.Event) {
 		val := e.TargetValue()
 		fc.value.Set(val)
-		fc.validate(val)
+		fc.edited(val)
 	})
 	if fc.onCommit != nil {
 		el.On("blur", func(dom.Event) { fc.onCommit() })
 	}
 
 	applyCommonAttrs(el, fc)
-	container.Child(el)
+	applyBusy(el, fc)
 
-	datalist := dom.NewElement("datalist").ID(listID)
-	for _, opt := range fc.Input.GetOptions() {
-		datalist.Child(dom.NewElement("option").Attr("value", opt.Key).Text(opt.Value))
+	fc.refreshOptions()
+	return el, bindNodes(dom.NewElement("datalist").ID(listID), fc.options)
+}
+
+// applyTextAttrs binds the control's placeholder and title through the
+// Translator ("placeholder.<field>", "title.<field>"), so they follow
+// SetLang like the label.
+func applyTextAttrs(el *dom.Element, fc *fieldComponent) {
+	name := fc.Input.FieldName()
+	if ph := fc.Input.GetPlaceholder(); ph != "" {
+		key := "placeholder." + name
+		el.BindAttrFunc("placeholder", func() string { return fc.t(key, ph) })
+	}
+	if title := fc.Input.GetTitle(); title != "" {
+		key := "title." + name
+		el.BindAttrFunc("title", func() string { return fc.t(key, title) })
 	}
-	container.Child(datalist)
 }
 
 func applyCommonAttrs(el *dom.Element, fc *fieldComponent) {
 	inp := fc.Input
-	if ph := inp.GetPlaceholder(); ph != "" {
-		el.Attr("placeholder", ph)
-	}
-	if title := inp.GetTitle(); title != "" {
-		el.Attr("title", title)
-	}
+	applyTextAttrs(el, fc)
 	for _, attr := range inp.GetAttributes() {
 		if attr.Value != "" {
 			el.Attr(attr.Key, attr.Value)
@@ -295,10 +492,46 @@ func applyCommonAttrs(el *dom.Element, fc *fieldComponent) {
 	if inp.IsRequired() {
 		el.Attr("required", "")
 	}
-	el.BindAttrBoolFunc("disabled", fc.isDisabledOrLocked)
+	// busy only for a LoadOptions field: a datalist while its options load
+	el.BindAttrBoolFunc("disabled", func() bool { return fc.isDisabledOrLocked() || fc.isBusy() })
 	if inp.IsReadonly() {
 		el.Attr("readonly", "")
 	}
+	applyRuleAttrs(el, fc, inp.HTMLName())
+	applyAria(el, fc)
+}
+
+// applyBusy marks a control aria-busy while its options load (only fields
+// with a LoadOptions loader ever are).
+func applyBusy(el *dom.Element, fc *fieldComponent) {
+	if fc.loading == nil {
+		return
+	}
+	el.BindAttrFunc("aria-busy", func() string {
+		if fc.loading.Get() {
+			return "true"
+		}
+		return "false"
+	})
+}
+
+// applyAria links a control to its error span and mirrors the field's state
+// for assistive tech: aria-describedby points at the span the error text
+// lands in, aria-invalid follows the err signal live, aria-required mirrors
+// the static required flag (a radiogroup or custom widget has no native
+// `required` to announce). Returns el for chaining.
+func applyAria(el *dom.Element, fc *fieldComponent) *dom.Element {
+	el.Attr("aria-describedby", fc.Input.ErrorID())
+	el.BindAttrFunc("aria-invalid", func() string {
+		if fc.err.Get() != "" {
+			return "true"
+		}
+		return "false"
+	})
+	if fc.Input.IsRequired() {
+		el.Attr("aria-required", "true")
+	}
+	return el
 }
 
 // RenderInput is kept for backward compatibility and as a standalone helper
@@ -308,6 +541,7 @@ func RenderInput(inp input.Input) *dom.Element {
 		value: dom.NewString(""),
 		err:   dom.NewString(""),
 	}
-	// Note: this Render() returns a div.tw-field containing the input + error span.
+	// Note: this Render() returns a div.tw-field containing the input + error span
+	// (DefaultLayout, since a standalone field has no form to inherit one from).
 	return fc.Render()
 }
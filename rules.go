@@ -0,0 +1,186 @@
+package form
+
+import (
+	"github.com/tinywasm/dom"
+	"github.com/tinywasm/fmt"
+	"github.com/tinywasm/model"
+)
+
+// Constraints are value rules for a field beyond what a model.Field carries
+// itself (NotNull, the Permitted length and charset, Unique). They are
+// enforced by Validate and ValidateData — and live, as the user types — and
+// emitted as the matching HTML attributes, so the browser and the backend
+// check the same thing.
+type Constraints struct {
+	// Min and Max bound the value, inclusive ("" = unbounded): numerically
+	// when both sides parse as numbers, else as text — which orders ISO
+	// dates and times ("2006-01-02", "15:04") correctly. Emitted as min/max.
+	Min, Max string
+	// Pattern is a regular expression the whole value must match, with HTML
+	// pattern semantics (implicitly anchored). Emitted as pattern. Only the
+	// subset the server and every browser read alike is accepted (see
+	// pattern); New rejects anything else.
+	Pattern string
+	// Enum lists the values accepted; empty accepts any.
+	Enum []string
+}
+
+type fieldConstraints struct {
+	field string
+	c     Constraints
+}
+
+// WithConstraints attaches c to the named field. See Constraints.
+func WithConstraints(field string, c Constraints) Option {
+	return func(f *Form) {
+		f.constraints = append(f.constraints, fieldConstraints{field, c})
+	}
+}
+
+// UniqueChecker answers whether value is already taken for a unique field
+// (model.Field.DB.Unique) by a record other than record — the one being
+// validated, so saving a record unchanged never collides with itself.
+type UniqueChecker interface {
+	Taken(field, value string, record model.Fielder) (bool, error)
+}
+
+// WithUniqueChecker injects the lookup unique fields are checked against on
+// Validate and ValidateData. Without one, Unique is left to the database.
+func WithUniqueChecker(u UniqueChecker) Option {
+	return func(f *Form) {
+		f.unique = u
+	}
+}
+
+// fieldRules are the schema-level rules of one input: its model.Field and
+// any Constraints attached to it.
+type fieldRules struct {
+	field   model.Field
+	c       Constraints
+	pattern *pattern // c.Pattern compiled; nil without one
+}
+
+// resolveRules collects the rules for a schema field; a Pattern outside
+// the supported subset (see pattern) is an error.
+func (f *Form) resolveRules(field model.Field) (*fieldRules, error) {
+	r := &fieldRules{field: field}
+	for _, fc := range f.constraints {
+		if fc.field == field.Name {
+			r.c = fc.c
+		}
+	}
+	if r.c.Pattern != "" {
+		pt, err := compilePattern(r.c.Pattern)
+		if err != nil {
+			return nil, err
+		}
+		r.pattern = pt
+	}
+	return r, nil
+}
+
+// check applies the rules to a non-empty value (emptiness is the input's
+// required check): the field's Permitted, then Min/Max, Pattern and Enum.
+func (r *fieldRules) check(val string) error {
+	if val == "" {
+		return nil
+	}
+	name := r.field.Name
+	if err := r.field.Permitted.Validate(name, val); err != nil {
+		return keyed(err, name, val)
+	}
+	if r.c.Min != "" && compareValues(val, r.c.Min) < 0 {
+		return message(KeyErrorMinimum, name, val, r.c.Min, name, "minimum", r.c.Min)
+	}
+	if r.c.Max != "" && compareValues(val, r.c.Max) > 0 {
+		return message(KeyErrorMaximum, name, val, r.c.Max, name, "maximum", r.c.Max)
+	}
+	if r.pattern != nil && !r.pattern.match(val) {
+		return message(KeyErrorFormat, name, val, "", name, "format", "invalid")
+	}
+	if len(r.c.Enum) > 0 {
+		for _, e := range r.c.Enum {
+			if e == val {
+				return nil
+			}
+		}
+		return errNotAllowed(name, val)
+	}
+	return nil
+}
+
+// compareValues orders a and b numerically when both are numbers, else as
+// text.
+func compareValues(a, b string) int {
+	x, errA := fmt.Convert(a).Float64()
+	y, errB := fmt.Convert(b).Float64()
+	if errA == nil && errB == nil {
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	}
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// validateField runs the i-th input's full check on val: the input's own
+// Validate, the schema rules, then — when withUnique — the unique lookup
+// against record. Live validation skips the lookup; Validate and
+// ValidateData make it.
+func (f *Form) validateField(i int, val string, record model.Fielder, withUnique bool) error {
+	if err := f.Inputs[i].Validate(val); err != nil {
+		return keyed(err, f.Inputs[i].FieldName(), val)
+	}
+	r := f.rules[i]
+	if err := r.check(val); err != nil {
+		return err
+	}
+	if !withUnique || val == "" || f.unique == nil || !r.field.IsUnique() {
+		return nil
+	}
+	taken, err := f.unique.Taken(r.field.Name, val, record)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return message(KeyErrorExists, r.field.Name, val, "", r.field.Name, val, "already", "exists")
+	}
+	return nil
+}
+
+// applyRuleAttrs emits the schema rules a control can enforce natively:
+// min/max/pattern from Constraints, minlength/maxlength from the field's
+// Permitted (text controls only — on a number they would mean nothing).
+func applyRuleAttrs(el *dom.Element, fc *fieldComponent, htmlName string) {
+	r := fc.rules
+	if r == nil {
+		return
+	}
+	if r.c.Min != "" {
+		el.Attr("min", r.c.Min)
+	}
+	if r.c.Max != "" {
+		el.Attr("max", r.c.Max)
+	}
+	if r.c.Pattern != "" && htmlName != "textarea" {
+		el.Attr("pattern", r.c.Pattern)
+	}
+	if htmlName == "number" {
+		return
+	}
+	if p := r.field.Permitted; p.Maximum > 0 {
+		el.Attr("maxlength", fmt.Convert(p.Maximum).String())
+	}
+	if p := r.field.Permitted; p.Minimum > 0 {
+		el.Attr("minlength", fmt.Convert(p.Minimum).String())
+	}
+}
@@ -0,0 +1,157 @@
+package form
+
+import "github.com/tinywasm/fmt"
+
+// snapshotVersion tags the encoding Snapshot emits. Bump it whenever the
+// token layout below changes; Restore rejects any other version outright
+// rather than guessing at an old layout.
+const snapshotVersion = "2"
+
+// Snapshot serialises the form's complete UI state — per-field value,
+// baseline and error, the locked flag, the focus intent and the host's
+// wizard step — into a compact string a host can stash (SPA route change,
+// bug report) and hand back to Restore later.
+//
+// The encoding is a flat sequence of length-prefixed tokens ("5:hello"), so
+// any byte is safe inside a value and no escaping table or JSON codec has to
+// ship in the WASM binary:
+//
+//	version, field count, then per field: name, value, baseline, error,
+//	NULL flags (see null.go: nulls then baseNulls, "0"/"1" each),
+//	then locked ("1"/"0"), focused id, step.
+//
+// Field names travel along so Restore can refuse a snapshot taken against a
+// different schema instead of pouring values into the wrong inputs.
+func (f *Form) Snapshot() string {
+	b := fmt.Convert()
+	writeToken(b, snapshotVersion)
+	writeToken(b, fmt.Convert(len(f.Inputs)).String())
+	for i, inp := range f.Inputs {
+		writeToken(b, inp.FieldName())
+		writeToken(b, f.valueSignals[i].Get())
+		writeToken(b, f.baseline[i])
+		writeToken(b, f.errorSignals[i].Get())
+		writeToken(b, flag(f.nulls[i])+flag(f.baseNulls[i]))
+	}
+	writeToken(b, flag(f.locked.Get()))
+	writeToken(b, f.focused)
+	writeToken(b, fmt.Convert(f.step).String())
+	return b.String()
+}
+
+// Restore applies a state produced by Snapshot. It is all-or-nothing: the
+// snapshot is fully decoded and checked against Inputs (same count, same
+// field names, same order) before anything is written, so a rejected
+// snapshot leaves the form exactly as it was.
+func (f *Form) Restore(snapshot string) error {
+	r := &tokenReader{src: snapshot}
+
+	if v := r.next(); v != snapshotVersion {
+		return fmt.Errf("form.Restore: unsupported snapshot version %q (want %q)", v, snapshotVersion)
+	}
+	count, err := fmt.Convert(r.next()).Int()
+	if err != nil || r.err {
+		return fmt.Errf("form.Restore: malformed snapshot header")
+	}
+	if count != len(f.Inputs) {
+		return fmt.Errf("form.Restore: snapshot has %d fields, form has %d", count, len(f.Inputs))
+	}
+
+	values := make([]string, count)
+	baseline := make([]string, count)
+	errs := make([]string, count)
+	nullFlags := make([]string, count)
+	for i, inp := range f.Inputs {
+		if name := r.next(); name != inp.FieldName() {
+			return fmt.Errf("form.Restore: snapshot field %d is %q, form expects %q", i, name, inp.FieldName())
+		}
+		values[i] = r.next()
+		baseline[i] = r.next()
+		errs[i] = r.next()
+		if nullFlags[i] = r.next(); len(nullFlags[i]) != 2 {
+			r.err = true
+		}
+	}
+	locked := r.next() == "1"
+	focused := r.next()
+	step, stepErr := fmt.Convert(r.next()).Int()
+	if r.err || stepErr != nil || r.pos != len(r.src) {
+		return fmt.Errf("form.Restore: malformed snapshot body")
+	}
+
+	// A debounced check or OnFieldInput still pending belongs to the edit
+	// being replaced — as in reset and LoadValues.
+	f.cancelPending()
+	for i, inp := range f.Inputs {
+		if !f.computed[i] {
+			f.valueSignals[i].Set(values[i])
+		}
+		f.errorSignals[i].Set(errs[i])
+		f.baseline[i] = baseline[i]
+		f.nulls[i] = f.nullable[i] && nullFlags[i][0] == '1'
+		f.baseNulls[i] = f.nullable[i] && nullFlags[i][1] == '1'
+		// Keep input internal state in sync for SSR mode — same as LoadValues.
+		if setter, ok := inp.(interface{ SetValues(...string) }); ok {
+			setter.SetValues(values[i])
+		}
+		f.resolveLabel(i)
+	}
+	f.rebase()
+	f.locked.Set(locked)
+	f.focused = focused
+	f.step = step
+	return nil
+}
+
+// flag encodes a bool as "1"/"0".
+func flag(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+// writeToken appends s to b as "<len>:<s>".
+func writeToken(b *fmt.Builder, s string) {
+	b.WriteString(fmt.Convert(len(s)).String())
+	b.WriteByte(':')
+	b.WriteString(s)
+}
+
+// tokenReader walks a Snapshot string token by token. A malformed token sets
+// err and yields "" from then on, so Restore checks once at the end instead
+// of after every read.
+type tokenReader struct {
+	src string
+	pos int
+	err bool
+}
+
+func (r *tokenReader) next() string {
+	if r.err {
+		return ""
+	}
+	n := 0
+	start := r.pos
+	for r.pos < len(r.src) && r.src[r.pos] != ':' {
+		c := r.src[r.pos]
+		if c < '0' || c > '9' {
+			r.err = true
+			return ""
+		}
+		n = n*10 + int(c-'0')
+		if n > len(r.src) {
+			r.err = true
+			return ""
+		}
+		r.pos++
+	}
+	if r.pos == start || r.pos >= len(r.src) || r.pos+1+n > len(r.src) {
+		r.err = true
+		return ""
+	}
+	r.pos++ // ':'
+	tok := r.src[r.pos : r.pos+n]
+	r.pos += n
+	return tok
+}
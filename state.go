@@ -0,0 +1,86 @@
+package form
+
+import "github.com/tinywasm/dom"
+
+// Focused returns the named field's live focus signal: true while focus is
+// inside the field. Nil for an unknown field.
+func (f *Form) Focused(fieldName string) *dom.SignalBool {
+	if i := f.inputIndex(fieldName); i >= 0 {
+		return f.active[i]
+	}
+	return nil
+}
+
+// Modified returns the named field's modified signal: true while its value
+// (or NULL state) differs from the baseline — the reactive, per-field
+// IsDirty. Nil for an unknown field.
+func (f *Form) Modified(fieldName string) *dom.SignalBool {
+	if i := f.inputIndex(fieldName); i >= 0 {
+		return f.modified[i]
+	}
+	return nil
+}
+
+// FormState aggregates the per-field state signals over the whole form.
+type FormState struct {
+	Total    int    // fields
+	Filled   int    // fields with a value — completion is Filled/Total
+	Touched  int    // fields left at least once (see Touched)
+	Visited  int    // fields focused at least once (see Visited)
+	Modified int    // fields differing from the baseline (see Modified)
+	Invalid  int    // fields showing an error
+	Focused  string // name of the field holding focus, "" if none
+}
+
+// State reads every field's state signals into a FormState. Called inside a
+// Bind*Func or Derive* closure it tracks them all, so a progress bar or a
+// section highlight re-renders as the user moves through the form.
+func (f *Form) State() FormState {
+	s := FormState{Total: len(f.Inputs)}
+	for i, inp := range f.Inputs {
+		if f.valueSignals[i].Get() != "" {
+			s.Filled++
+		}
+		if f.touched[i].Get() {
+			s.Touched++
+		}
+		if f.visited[i].Get() {
+			s.Visited++
+		}
+		if f.modified[i].Get() {
+			s.Modified++
+		}
+		if f.errorSignals[i].Get() != "" {
+			s.Invalid++
+		}
+		if f.active[i].Get() {
+			s.Focused = inp.FieldName()
+		}
+	}
+	return s
+}
+
+// isModified reports whether the i-th input differs from its baseline.
+func (f *Form) isModified(i int) bool {
+	return f.valueSignals[i].Get() != f.baseline[i] || f.isNull(i) != f.baseNulls[i]
+}
+
+// deriveModified builds the i-th input's Modified signal. The baseline is a
+// plain slice, so rebased stands in for it as the dependency.
+func (f *Form) deriveModified(i int) *dom.SignalBool {
+	return dom.DeriveBool(func() bool {
+		f.rebased.Get()
+		if i >= len(f.valueSignals) {
+			return false // disposed
+		}
+		return f.isModified(i)
+	})
+}
+
+// rebase signals that baselines or NULL flags changed, so every Modified
+// signal re-derives.
+func (f *Form) rebase() {
+	if f.rebased != nil {
+		f.rebased.Toggle()
+	}
+}
@@ -0,0 +1,89 @@
+package form
+
+import "github.com/tinywasm/model"
+
+// BeforeValidate registers a hook Submit runs first, before syncing and
+// validating — the place to normalise values (trim, upper-case a code)
+// with SetValues so what is checked and sent is the normalised form.
+// Chainable.
+func (f *Form) BeforeValidate(fn func()) *Form {
+	f.beforeValidate = fn
+	return f
+}
+
+// BeforeSubmit registers a gate between a valid form and OnSubmit: fn gets
+// the synced record and calls proceed(true) to send it or proceed(false) to
+// drop it — after a confirm dialog, say, so it may answer later. The form
+// stays submitting meanwhile. Chainable.
+func (f *Form) BeforeSubmit(fn func(data model.Fielder, proceed func(ok bool))) *Form {
+	f.beforeSubmit = fn
+	return f
+}
+
+// AfterSuccess registers a hook run when OnSubmit's done reports success,
+// after the automatic reset (see NoResetOnSuccess) — so it may load the
+// saved record back. Chainable.
+func (f *Form) AfterSuccess(fn func(data model.Fielder)) *Form {
+	f.afterSuccess = fn
+	return f
+}
+
+// AfterError registers a hook run when OnSubmit's done reports an error,
+// and for the validation or conversion error of a Submit that waited on
+// uploads — any other Submit returns those to its caller. Chainable.
+func (f *Form) AfterError(fn func(err error)) *Form {
+	f.afterError = fn
+	return f
+}
+
+// CancelSubmit abandons the submission in flight — waiting on uploads, on
+// BeforeSubmit or on OnSubmit's done — and turns submitting off. A done or
+// proceed that arrives for it later is ignored. Chainable.
+func (f *Form) CancelSubmit() *Form {
+	f.attempt++
+	f.submitQueued = false
+	f.submitting.Set(false)
+	return f
+}
+
+// dispatch hands the validated record to BeforeSubmit, then OnSubmit, as
+// attempt seq; anything answering for an older attempt is ignored.
+func (f *Form) dispatch() {
+	f.attempt++
+	seq := f.attempt
+	f.submitting.Set(true)
+	send := func() {
+		f.onSubmit(f.data, func(err error) {
+			if seq != f.attempt {
+				return // cancelled or superseded
+			}
+			f.submitting.Set(false)
+			if err != nil {
+				if f.afterError != nil {
+					f.afterError(err)
+				}
+				return
+			}
+			if !f.noResetOnSuccess {
+				f.reset()
+			}
+			if f.afterSuccess != nil {
+				f.afterSuccess(f.data)
+			}
+		})
+	}
+	if f.beforeSubmit == nil {
+		send()
+		return
+	}
+	f.beforeSubmit(f.data, func(ok bool) {
+		if seq != f.attempt {
+			return
+		}
+		if !ok {
+			f.submitting.Set(false)
+			return
+		}
+		send()
+	})
+}
@@ -5,11 +5,47 @@ import (
 	"github.com/tinywasm/model"
 )
 
+// ConvertError reports a field whose text could not be converted to the Go
+// type its storage needs — "12abc" in a Number field. The struct field is
+// left as it was, never silently zeroed.
+type ConvertError struct {
+	Field   string          // field name (Input.FieldName)
+	Value   string          // the text that failed to convert
+	Storage model.FieldType // what it was being converted to
+}
+
+func (e *ConvertError) Error() string {
+	return fmt.Err(e.Field, "invalid", e.Storage.String()).Error()
+}
+
+// ConvertErrors is what SyncValues returns when one or more fields failed to
+// convert: every failure, in Inputs order — not just the first.
+type ConvertErrors []*ConvertError
+
+func (es ConvertErrors) Error() string {
+	msg := ""
+	for i, e := range es {
+		if i > 0 {
+			msg += "; "
+		}
+		msg += e.Error()
+	}
+	return msg
+}
+
 // SyncValues copies all input values back into the bound struct
 // via the Fielder's Pointers() method.
+//
+// A nullable field (a **T or Nullable pointer — see null.go) left empty is
+// set to NULL rather than zeroed. A field with a Codec (see WithCodec) goes
+// through its ToStorage first. A value that cannot be converted to its
+// field's storage type is not written; it is reported on that field's error
+// signal like any validation error, and SyncValues returns the lot as
+// ConvertErrors.
 func (f *Form) SyncValues(data model.Fielder) error {
 	pointers := data.Pointers()
 	schema := data.Schema()
+	var errs ConvertErrors
 
 	for i, inp := range f.Inputs {
 		idx := f.fieldIndices[i]
@@ -34,15 +70,36 @@ func (f *Form) SyncValues(data model.Fielder) error {
 		values := []string{val}
 
 		ptr := pointers[idx]
-		field := schema[idx]
+		st := storageOf(ptr, schema[idx].Type.Storage())
 
-		if val == "" {
+		_, _, nullable := nullInner(ptr)
+		if val == "" && f.isNull(i) {
+			setNull(ptr)
+			continue
+		}
+		if val == "" && !nullable {
 			// Zero the field
-			zeroField(ptr, field.Type.Storage())
+			zeroField(ptr, st)
 			continue
 		}
 
-		writeField(ptr, field.Type.Storage(), values)
+		ok := true
+		if c := f.codecs[i]; c != nil && val != "" {
+			stored, err := c.ToStorage(val)
+			values[0], ok = stored, err == nil
+		}
+		if ok {
+			if nullable {
+				ok = writeNullable(ptr, st, values[0])
+			} else {
+				ok = writeField(ptr, st, values)
+			}
+		}
+		if !ok {
+			ce := &ConvertError{Field: inp.FieldName(), Value: val, Storage: st}
+			errs = append(errs, ce)
+			f.setError(i, ce)
+		}
 	}
 
 	// A hidden PK (New skipped it — see that function's comment) has no
@@ -65,9 +122,36 @@ func (f *Form) SyncValues(data model.Fielder) error {
 		writeField(ptr, model.FieldText, []string{f.idGen.NewID()})
 	}
 
+	if len(errs) > 0 {
+		return errs
+	}
 	return nil
 }
 
+// storageOf is the storage type a field's pointer actually holds, falling
+// back to declared (the input's Storage) for a pointer it does not know. The
+// two differ when a Codec bridges them — a Date input, text by kind, over an
+// int64 unix column — and the pointer is what gets written.
+func storageOf(ptr any, declared model.FieldType) model.FieldType {
+	ptr, _, _ = nullInner(ptr)
+	switch ptr.(type) {
+	case *string:
+		if declared == model.FieldRaw {
+			return declared
+		}
+		return model.FieldText
+	case *int64, *int, *int32, *uint, *uint32, *uint64:
+		return model.FieldInt
+	case *float64, *float32:
+		return model.FieldFloat
+	case *bool:
+		return model.FieldBool
+	case *[]byte:
+		return model.FieldBlob
+	}
+	return declared
+}
+
 // zeroField sets a field to its zero value via its pointer.
 func zeroField(ptr any, ft model.FieldType) {
 	switch ft {
@@ -76,41 +160,226 @@ func zeroField(ptr any, ft model.FieldType) {
 			*p = ""
 		}
 	case model.FieldInt:
-		if p, ok := ptr.(*int64); ok {
+		switch p := ptr.(type) {
+		case *int64:
+			*p = 0
+		case *int:
+			*p = 0
+		case *int32:
+			*p = 0
+		case *uint:
+			*p = 0
+		case *uint32:
+			*p = 0
+		case *uint64:
 			*p = 0
 		}
 	case model.FieldFloat:
-		if p, ok := ptr.(*float64); ok {
+		switch p := ptr.(type) {
+		case *float64:
+			*p = 0
+		case *float32:
 			*p = 0
 		}
 	case model.FieldBool:
 		if p, ok := ptr.(*bool); ok {
 			*p = false
 		}
+	case model.FieldBlob:
+		if p, ok := ptr.(*[]byte); ok {
+			*p = nil
+		}
 	}
 }
 
-// writeField writes string values into a field via its pointer.
-func writeField(ptr any, ft model.FieldType, values []string) {
+// Range limits for the narrow integer and float storage types.
+const (
+	maxInt32   = 1<<31 - 1
+	minInt32   = -1 << 31
+	maxUint32  = 1<<32 - 1
+	maxFloat32 = 3.40282346638528859811704183484516925440e+38
+)
+
+// writeField writes string values into a field via its pointer. It reports
+// false — and writes nothing — when the text does not convert to the
+// storage type, or does not fit the pointer's Go type (5000000000 into an
+// int32 is an error, not a wrap-around).
+func writeField(ptr any, ft model.FieldType, values []string) bool {
 	switch ft {
 	case model.FieldText:
 		if p, ok := ptr.(*string); ok {
 			*p = values[0]
 		}
 	case model.FieldInt:
-		if p, ok := ptr.(*int64); ok {
-			val, _ := fmt.Convert(values[0]).Int64()
-			*p = val
+		switch p := ptr.(type) {
+		case *uint, *uint32, *uint64:
+			// Digits only: fmt's unsigned parser wraps "-1" round to the max.
+			if !allDigits(values[0]) || values[0] == "" {
+				return false
+			}
+			val, err := fmt.Convert(values[0]).Uint64()
+			if err != nil {
+				return false
+			}
+			switch p := p.(type) {
+			case *uint64:
+				*p = val
+			case *uint:
+				if uint64(uint(val)) != val {
+					return false
+				}
+				*p = uint(val)
+			case *uint32:
+				if val > maxUint32 {
+					return false
+				}
+				*p = uint32(val)
+			}
+		case *int64, *int, *int32:
+			// The Int kind first: fmt's parser stops at a '.', so "1.5" would
+			// otherwise land as 1 without complaint.
+			if model.Int().Validate(values[0]) != nil {
+				return false
+			}
+			val, err := fmt.Convert(values[0]).Int64()
+			if err != nil {
+				return false
+			}
+			switch p := p.(type) {
+			case *int64:
+				*p = val
+			case *int:
+				if int64(int(val)) != val {
+					return false
+				}
+				*p = int(val)
+			case *int32:
+				if val < minInt32 || val > maxInt32 {
+					return false
+				}
+				*p = int32(val)
+			}
 		}
 	case model.FieldFloat:
-		if p, ok := ptr.(*float64); ok {
-			val, _ := fmt.Convert(values[0]).Float64()
+		switch p := ptr.(type) {
+		case *float64:
+			val, err := fmt.Convert(values[0]).Float64()
+			if err != nil {
+				return false
+			}
 			*p = val
+		case *float32:
+			val, err := fmt.Convert(values[0]).Float64()
+			if err != nil || val > maxFloat32 || val < -maxFloat32 {
+				return false
+			}
+			*p = float32(val)
 		}
 	case model.FieldBool:
 		if p, ok := ptr.(*bool); ok {
-			val, _ := fmt.Convert(values[0]).Bool()
+			val, ok := parseBool(values[0])
+			if !ok {
+				return false
+			}
 			*p = val
 		}
+	case model.FieldBlob:
+		if p, ok := ptr.(*[]byte); ok {
+			*p = []byte(values[0])
+		}
+	}
+	return true
+}
+
+// readField is writeField's inverse: the canonical text of the value behind
+// ptr (read per storageOf), "" for a nil pointer or an unsupported type.
+// Unlike model.ReadValues fed through fmt.Convert it renders every width of
+// int losslessly (the full uint64 range included) and a []byte as its bytes.
+func readField(ptr any, declared model.FieldType) string {
+	ptr, null, _ := nullInner(ptr)
+	if null {
+		return ""
+	}
+	switch storageOf(ptr, declared) {
+	case model.FieldText, model.FieldRaw:
+		if p, ok := ptr.(*string); ok && p != nil {
+			return *p
+		}
+	case model.FieldInt:
+		switch p := ptr.(type) {
+		case *int64:
+			if p != nil {
+				return fmt.Convert(*p).String()
+			}
+		case *int:
+			if p != nil {
+				return fmt.Convert(int64(*p)).String()
+			}
+		case *int32:
+			if p != nil {
+				return fmt.Convert(int64(*p)).String()
+			}
+		case *uint:
+			if p != nil {
+				return formatUint(uint64(*p))
+			}
+		case *uint32:
+			if p != nil {
+				return formatUint(uint64(*p))
+			}
+		case *uint64:
+			if p != nil {
+				return formatUint(*p)
+			}
+		}
+	case model.FieldFloat:
+		switch p := ptr.(type) {
+		case *float64:
+			if p != nil {
+				return fmt.Convert(*p).String()
+			}
+		case *float32:
+			if p != nil {
+				return fmt.Convert(*p).String()
+			}
+		}
+	case model.FieldBool:
+		if p, ok := ptr.(*bool); ok && p != nil {
+			return fmt.Convert(*p).String()
+		}
+	case model.FieldBlob:
+		if p, ok := ptr.(*[]byte); ok && p != nil {
+			return string(*p) // a Go string keeps any bytes; see Base64
+		}
+	}
+	return ""
+}
+
+// formatUint renders n in decimal; fmt's conversion gives up above
+// math.MaxInt64.
+func formatUint(n uint64) string {
+	if n == 0 {
+		return "0"
+	}
+	var buf [20]byte
+	i := len(buf)
+	for n > 0 {
+		i--
+		buf[i] = byte('0' + n%10)
+		n /= 10
+	}
+	return string(buf[i:])
+}
+
+// parseBool is fmt's Bool plus the HTML checkbox's own "on"/"off" — a
+// checkbox with no value attribute submits "on" when ticked.
+func parseBool(s string) (bool, bool) {
+	switch s {
+	case "on":
+		return true, true
+	case "off":
+		return false, true
 	}
+	val, err := fmt.Convert(s).Bool()
+	return val, err == nil
 }
@@ -0,0 +1,46 @@
+package form_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tinywasm/model"
+)
+
+func TestValidateData_DeleteSkipsFieldRules(t *testing.T) {
+	f := productForm(t, &productRecord{})
+	stale := &productRecord{Code: "x", Stock: 9000} // fails pattern, Max and required
+	if err := f.ValidateData(model.ActionCreate, stale); err == nil {
+		t.Fatal("create should validate the fields")
+	}
+	if err := f.ValidateData(model.ActionDelete, stale); err != nil {
+		t.Errorf("delete should not validate the fields: %v", err)
+	}
+
+	f.RequireOn(model.ActionDelete, "Name")
+	if err := f.ValidateData(model.ActionDelete, stale); err == nil {
+		t.Error("delete should enforce RequireOn")
+	}
+	stale.Name = "Gadget"
+	if err := f.ValidateData(model.ActionDelete, stale); err != nil {
+		t.Errorf("RequireOn satisfied: %v", err)
+	}
+}
+
+func TestValidateData_SkipAndRequireOn(t *testing.T) {
+	f := productForm(t, &productRecord{})
+	f.SkipOn(model.ActionUpdate, "Code").RequireOn(model.ActionCreate, "Size")
+
+	noCode := &productRecord{Name: "Gadget", Stock: 1, Size: "small"}
+	if err := f.ValidateData(model.ActionUpdate, noCode); err != nil {
+		t.Errorf("update should skip Code: %v", err)
+	}
+	if err := f.ValidateData(model.ActionCreate, noCode); err == nil {
+		t.Error("create should still validate Code")
+	}
+
+	noSize := &productRecord{Code: "CD456", Name: "Gadget", Stock: 1}
+	if err := f.ValidateData(model.ActionCreate, noSize); err == nil || !strings.Contains(err.Error(), "Size is required") {
+		t.Errorf("create should require Size, got %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+package form_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tinywasm/fmt"
+	"github.com/tinywasm/form"
+	"github.com/tinywasm/model"
+)
+
+// customerRecord picks its customer from a search too large for a select.
+type customerRecord struct {
+	Customer string
+	search   form.SearchProvider
+}
+
+func (m *customerRecord) Schema() []model.Field {
+	return []model.Field{{Name: "Customer", Type: form.Combobox(m.search)}}
+}
+
+func (m *customerRecord) Pointers() []any { return []any{&m.Customer} }
+
+var customers = []fmt.KeyValue{
+	{Key: "c-17", Value: "Acme Corp"},
+	{Key: "c-42", Value: "Acme Labs"},
+	{Key: "c-99", Value: "Globex"},
+}
+
+func TestCombobox_RendersComboboxPattern(t *testing.T) {
+	f, err := form.New("parent", &customerRecord{}, &testIDGen{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	html := f.String()
+	for _, want := range []string{
+		"role='combobox'",
+		"aria-expanded='false'",
+		"role='listbox'",
+		"aria-autocomplete='list'",
+		"type='hidden' name='Customer'",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("render missing %q:\n%s", want, html)
+		}
+	}
+	// ARIA 1.2: the focusable box is the combobox and carries the error
+	// wiring a screen reader announces.
+	box := html[strings.Index(html, "<input"):]
+	box = box[:strings.Index(box, ">")]
+	for _, want := range []string{"role='combobox'", "aria-invalid='false'", "aria-describedby="} {
+		if !strings.Contains(box, want) {
+			t.Errorf("box missing %q: %s", want, box)
+		}
+	}
+}
+
+func TestCombobox_FollowsLockAndTheme(t *testing.T) {
+	f, _ := form.New("parent", &customerRecord{}, &testIDGen{},
+		form.WithTheme(form.Theme{Input: "form-control"}))
+	f.SetLocked(true)
+	html := f.String()
+	box := html[strings.Index(html, "<input"):]
+	box = box[:strings.Index(box, ">")]
+	if !strings.Contains(box, "form-control") || !strings.Contains(box, "disabled") {
+		t.Errorf("box should carry the theme class and follow SetLocked: %s", box)
+	}
+}
+
+func TestCombobox_StoresKeyShowsLabel(t *testing.T) {
+	f, _ := form.New("parent", &customerRecord{}, &testIDGen{})
+	f.SetOptions("Customer", customers...)
+	f.SetValues("Customer", "c-42")
+
+	html := f.String()
+	if !strings.Contains(html, "value='Acme Labs'") {
+		t.Errorf("box should show the label of c-42:\n%s", html)
+	}
+	if !strings.Contains(html, "name='Customer' value='c-42'") {
+		t.Errorf("hidden input should carry the key:\n%s", html)
+	}
+
+	dst := &customerRecord{}
+	if err := f.SyncValues(dst); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Customer != "c-42" {
+		t.Errorf("SyncValues stored %q, want the key c-42", dst.Customer)
+	}
+}
+
+func TestCombobox_ValidateClosedEnumWithOptions(t *testing.T) {
+	f, _ := form.New("parent", &customerRecord{}, &testIDGen{})
+	validator, ok := f.Input("Customer").(interface{ Validate(string) error })
+	if !ok {
+		t.Fatal("combobox should validate")
+	}
+	if err := validator.Validate("anything"); err != nil {
+		t.Errorf("search-backed combobox accepts any key, got %v", err)
+	}
+	f.SetOptions("Customer", customers...)
+	if err := validator.Validate("c-00"); err == nil {
+		t.Error("with static Options, an unknown key should fail")
+	}
+	if err := validator.Validate("c-17"); err != nil {
+		t.Errorf("known key rejected: %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+package form_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tinywasm/fmt"
+	"github.com/tinywasm/form"
+	"github.com/tinywasm/input"
+	"github.com/tinywasm/model"
+)
+
+type lineRecord struct {
+	Qty   int64
+	Price float64
+	Total float64
+	Label string
+}
+
+func (m *lineRecord) Schema() []model.Field {
+	return []model.Field{
+		{Name: "Qty", Type: input.Number()},
+		{Name: "Price", Type: input.Decimal()},
+		{Name: "Total", Type: input.Decimal()},
+		{Name: "Label", Type: input.Textarea()},
+	}
+}
+
+func (m *lineRecord) Pointers() []any { return []any{&m.Qty, &m.Price, &m.Total, &m.Label} }
+
+// lineTotal is Qty × Price, or "" until both are numbers.
+func lineTotal(value func(string) string) string {
+	qty, err1 := fmt.Convert(value("Qty")).Int64()
+	price, err2 := fmt.Convert(value("Price")).Float64()
+	if value("Qty") == "" || value("Price") == "" || err1 != nil || err2 != nil {
+		return ""
+	}
+	return fmt.Convert(float64(qty) * price).String()
+}
+
+func TestCompute_DerivesLiveAndSyncs(t *testing.T) {
+	f, err := form.New("parent", &lineRecord{Qty: 2, Price: 1.5}, &testIDGen{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Compute("Total", lineTotal).
+		Compute("Label", func(value func(string) string) string {
+			return value("Qty") + " × " + value("Price") + " = " + value("Total")
+		})
+
+	html := f.String()
+	if !strings.Contains(html, "type='text' readonly='' value='3'") {
+		t.Errorf("Total should render read-only with 3:\n%s", html)
+	}
+
+	f.SetValues("Qty", "4")
+	dst := &lineRecord{}
+	if err := f.SyncValues(dst); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Total != 6 || dst.Label != "4 × 1.5 = 6" {
+		t.Errorf("SyncValues wrote Total=%v Label=%q", dst.Total, dst.Label)
+	}
+
+	f.SetValues("Total", "999") // ignored: derived
+	f.SyncValues(dst)
+	if dst.Total != 6 {
+		t.Errorf("SetValues overrode a computed field: %v", dst.Total)
+	}
+}
+
+func TestCompute_DirtyAndLoad(t *testing.T) {
+	f, _ := form.New("parent", &lineRecord{}, &testIDGen{})
+	f.Compute("Total", lineTotal)
+
+	f.LoadValues(&lineRecord{Qty: 3, Price: 2, Total: 0})
+	if f.IsDirty() {
+		t.Error("a freshly loaded record is pristine, computed fields included")
+	}
+	dst := &lineRecord{}
+	f.SyncValues(dst)
+	if dst.Total != 6 {
+		t.Errorf("Total after load = %v, want 6 (computed, not the stored 0)", dst.Total)
+	}
+
+	f.SetValues("Qty", "5")
+	f.SetValues("Qty", "3") // back to the loaded value
+	if f.IsDirty() {
+		t.Error("sources back at baseline: not dirty")
+	}
+
+	g, _ := form.New("parent", &lineRecord{Qty: 1, Price: 1}, &testIDGen{})
+	g.Compute("Total", lineTotal).IgnoreDirty("Total", "Price")
+	g.SetValues("Price", "7")
+	if g.IsDirty() {
+		t.Error("only ignored fields changed: not dirty")
+	}
+	g.SetValues("Qty", "2")
+	if !g.IsDirty() {
+		t.Error("a tracked source changed: dirty")
+	}
+
+	g.Reset()
+	g.SyncValues(dst)
+	if dst.Total != 0 || g.IsDirty() {
+		t.Errorf("after Reset: Total=%v dirty=%v", dst.Total, g.IsDirty())
+	}
+}
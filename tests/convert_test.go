@@ -0,0 +1,57 @@
+package form_test
+
+import (
+	"testing"
+
+	"github.com/tinywasm/form"
+	"github.com/tinywasm/model"
+)
+
+// TestSyncValues_ReportsConversionErrors: "12abc" in a Number field must
+// surface as an error on that field, not land in the struct as 0.
+func TestSyncValues_ReportsConversionErrors(t *testing.T) {
+	f, _ := form.New("parent-id", &WidgetsModel{Name: "A", Price: 100}, &testIDGen{})
+	f.SetValues("Price", "12abc")
+
+	dst := &WidgetsModel{Price: 7}
+	err := f.SyncValues(dst)
+	errs, ok := err.(form.ConvertErrors)
+	if !ok || len(errs) != 1 {
+		t.Fatalf("SyncValues error = %#v, want ConvertErrors with one entry", err)
+	}
+	if errs[0].Field != "Price" || errs[0].Value != "12abc" || errs[0].Storage != model.FieldInt {
+		t.Errorf("ConvertError = %+v, want Price/12abc/FieldInt", *errs[0])
+	}
+	if dst.Price != 7 {
+		t.Errorf("Price = %d, want 7 (an unconvertible value must not be written)", dst.Price)
+	}
+	if dst.Name != "A" {
+		t.Errorf("Name = %q, want %q (other fields still sync)", dst.Name, "A")
+	}
+
+	// A fraction in an int field is a conversion error too, not a truncation.
+	f.SetValues("Price", "1.5")
+	if err := f.SyncValues(dst); err == nil {
+		t.Error("expected \"1.5\" in an int field to be reported, got nil")
+	}
+}
+
+func TestSubmit_RefusesUnconvertibleValue(t *testing.T) {
+	// Decimal() permits '.' and '-' anywhere, so "1-2" passes Validate but
+	// is not a float — only the conversion catches it.
+	rec := &priceRecord{Price: 3}
+	f, _ := form.New("parent", rec, &testIDGen{})
+	called := false
+	f.OnSubmit(func(model.Fielder, func(error)) { called = true })
+
+	f.SetValues("Price", "1-2")
+	if err := f.Submit(); err == nil {
+		t.Fatal("expected Submit to fail on an unconvertible value")
+	}
+	if called {
+		t.Error("OnSubmit must not run with a record that failed conversion")
+	}
+	if rec.Price != 3 {
+		t.Errorf("Price = %v, want 3 (untouched)", rec.Price)
+	}
+}
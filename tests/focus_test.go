@@ -0,0 +1,53 @@
+package form_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tinywasm/form"
+)
+
+func TestFocusField_RecordsIntent(t *testing.T) {
+	f := productForm(t, &productRecord{})
+	f.FocusField("Stock")
+	if id := f.FocusedFieldID(); !strings.HasSuffix(id, "Stock") {
+		t.Errorf("FocusedFieldID = %q, want the Stock input", id)
+	}
+	f.FocusField("nope")
+	if id := f.FocusedFieldID(); !strings.HasSuffix(id, "Stock") {
+		t.Errorf("an unknown name changed the intent: %q", id)
+	}
+}
+
+func TestSubmit_FocusesFirstInvalidField(t *testing.T) {
+	f := productForm(t, &productRecord{Code: "CD456", Name: "Gadget", Stock: 900, Size: "medium"})
+	f.Focus()
+	if err := f.Submit(); err == nil {
+		t.Fatal("Stock and Size are invalid")
+	}
+	if id := f.FocusedFieldID(); !strings.HasSuffix(id, "Stock") {
+		t.Errorf("FocusedFieldID = %q, want the first invalid field, Stock", id)
+	}
+}
+
+func TestWithFocusSkip(t *testing.T) {
+	f, err := form.New("parent", &lineRecord{Qty: 1}, &testIDGen{}, form.WithFocusSkip())
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Compute("Qty", func(func(string) string) string { return "1" })
+	f.Focus()
+	if id := f.FocusedFieldID(); !strings.HasSuffix(id, "Price") {
+		t.Errorf("Focus = %q, want Price: the computed Qty is skipped", id)
+	}
+	f.FocusField("Qty")
+	if id := f.FocusedFieldID(); !strings.HasSuffix(id, "Price") {
+		t.Errorf("FocusField on a skipped field moved focus to %q", id)
+	}
+
+	f.Reset()
+	f.SetLocked(true).Focus()
+	if id := f.FocusedFieldID(); id != "" {
+		t.Errorf("a locked form has nothing to focus, got %q", id)
+	}
+}
@@ -0,0 +1,56 @@
+package form_test
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateGroup_OnlyTaggedFieldsShowErrors(t *testing.T) {
+	f := productForm(t, &productRecord{Code: "bad", Name: "Gadget", Stock: 900, Size: "small"})
+	f.Group("step1", "Name", "Size").Group("step2", "Code", "Stock")
+
+	if err := f.ValidateGroup("step1"); err != nil {
+		t.Errorf("step1 is valid: %v", err)
+	}
+	if html := f.String(); strings.Contains(html, "aria-invalid='true'") {
+		t.Errorf("validating step1 flagged a field:\n%s", html)
+	}
+
+	if err := f.ValidateGroup("step2"); err == nil {
+		t.Fatal("step2 should fail")
+	}
+	html := f.String()
+	if n := strings.Count(html, "aria-invalid='true'"); n != 2 {
+		t.Errorf("want both step2 fields flagged, got %d:\n%s", n, html)
+	}
+
+	f.SetValues("Code", "CD456").SetValues("Stock", "5")
+	if err := f.ValidateFields("Code", "Stock"); err != nil {
+		t.Errorf("fixed fields: %v", err)
+	}
+	if html := f.String(); strings.Contains(html, "aria-invalid='true'") {
+		t.Errorf("ValidateFields should clear fixed errors:\n%s", html)
+	}
+	if err := f.ValidateGroup("nope"); err != nil {
+		t.Errorf("unknown group: %v", err)
+	}
+}
+
+func TestValidateFields_DirtyFields(t *testing.T) {
+	f := productForm(t, &productRecord{Code: "bad", Name: "Gadget", Stock: 5, Size: "small"})
+	if got := f.DirtyFields(); len(got) != 0 {
+		t.Errorf("pristine form: DirtyFields = %v", got)
+	}
+	f.SetValues("Size", "large")
+	got := f.DirtyFields()
+	if len(got) != 1 || got[0] != "Size" {
+		t.Fatalf("DirtyFields = %v, want [Size]", got)
+	}
+	// The invalid, untouched Code is left alone.
+	if err := f.ValidateFields(got...); err != nil {
+		t.Errorf("only Size validated: %v", err)
+	}
+	if f.ChangedField() != "" {
+		t.Errorf("ChangedField before any commit = %q", f.ChangedField())
+	}
+}
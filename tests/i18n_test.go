@@ -0,0 +1,118 @@
+package form_test
+
+import (
+	"testing"
+
+	"github.com/tinywasm/fmt"
+	"github.com/tinywasm/form"
+)
+
+// dictTranslator is a two-language test dictionary.
+type dictTranslator map[string]map[string]string
+
+func (d dictTranslator) Translate(lang, key string) (string, bool) {
+	s, ok := d[lang][key]
+	return s, ok
+}
+
+var testDict = dictTranslator{
+	"es": {
+		"label.nombre":        "Nombre",
+		"label.gender":        "Género",
+		"option.gender.m":     "Masculino",
+		form.KeySubmit:        "Guardar",
+		form.KeySubmitLoading: "Guardando",
+	},
+	"en": {
+		"label.nombre":    "Name",
+		"option.gender.m": "Male",
+	},
+}
+
+func TestForm_Translator(t *testing.T) {
+	f, _ := form.New("app", &anatomyStruct{}, &testIDGen{}, form.WithTranslator(testDict))
+	f.SetOptions("gender", fmt.KeyValue{Key: "m", Value: "M"})
+
+	// No language yet: every text falls back to the form's own.
+	if html := f.String(); !fmt.Contains(html, ">nombre</label>") || !fmt.Contains(html, ">Submit</button>") {
+		t.Errorf("expected untranslated fallbacks before SetLang, got: %s", html)
+	}
+
+	f.SetLang("es")
+	html := f.String()
+	for _, want := range []string{">Nombre</label>", "title='Género'", "<span>Masculino</span>", ">Guardar</button>"} {
+		if !fmt.Contains(html, want) {
+			t.Errorf("es: expected %q in: %s", want, html)
+		}
+	}
+
+	// Switching language re-renders the same form; missing keys fall back.
+	f.SetLang("en")
+	html = f.String()
+	for _, want := range []string{">Name</label>", "<span>Male</span>", ">Submit</button>", ">gender</label>"} {
+		if !fmt.Contains(html, want) {
+			t.Errorf("en: expected %q in: %s", want, html)
+		}
+	}
+	if f.Lang() != "en" {
+		t.Errorf("Lang() = %q, want %q", f.Lang(), "en")
+	}
+	if got := f.Translate("label.nombre", "x"); got != "Name" {
+		t.Errorf("Translate = %q, want %q", got, "Name")
+	}
+}
+
+func TestForm_TranslatesMessagesByKey(t *testing.T) {
+	dict := dictTranslator{"es": {
+		form.KeyErrorMaximum:    "{label}: máximo {limit}",
+		form.KeyErrorMaxLength:  "{field}: hasta {limit} caracteres",
+		form.KeyErrorNotAllowed: "{value} no es una opción",
+		"label.Stock":           "Existencias",
+	}}
+	f, _ := form.New("parent", &productRecord{Code: "CD456", Name: "Gadget", Stock: 900, Size: "medium"}, &testIDGen{},
+		form.WithTranslator(dict),
+		form.WithConstraints("Stock", form.Constraints{Max: "500"}),
+		form.WithConstraints("Size", form.Constraints{Enum: []string{"small", "large"}}))
+	f.SetValues("Name", "Gadget Pro Max")
+	err := f.ValidateFields("Name", "Stock", "Size")
+	if m, ok := err.(*form.Message); !ok || m.Key != form.KeyErrorMaxLength || m.Limit != "10" {
+		t.Errorf("want a keyed maxlength Message, got %#v", err)
+	}
+
+	// One entry per kind of failure, whatever the field or the limit.
+	f.SetLang("es")
+	html := f.String()
+	for _, want := range []string{
+		">Name: hasta 10 caracteres</span>",
+		">Existencias: máximo 500</span>",
+		">medium no es una opción</span>",
+	} {
+		if !fmt.Contains(html, want) {
+			t.Errorf("expected %q in: %s", want, html)
+		}
+	}
+	f.SetLang("en")
+	if html := f.String(); !fmt.Contains(html, ">Stock maximum 500</span>") {
+		t.Errorf("a missing key falls back to the untranslated text: %s", html)
+	}
+}
+
+func TestForm_TranslatesPlaceholderAndTitle(t *testing.T) {
+	dict := dictTranslator{"es": {
+		"placeholder.nombre": "Su nombre",
+		"title.nombre":       "Nombre completo",
+	}}
+	f, _ := form.New("app", &anatomyStruct{}, &testIDGen{}, form.WithTranslator(dict))
+	f.Input("nombre").(interface{ SetPlaceholder(...string) }).SetPlaceholder("Your name")
+
+	if html := f.String(); !fmt.Contains(html, "placeholder='Your name'") || !fmt.Contains(html, "title='nombre'") {
+		t.Errorf("untranslated placeholder and title before SetLang: %s", html)
+	}
+	f.SetLang("es")
+	html := f.String()
+	for _, want := range []string{"placeholder='Su nombre'", "title='Nombre completo'"} {
+		if !fmt.Contains(html, want) {
+			t.Errorf("es: expected %q in: %s", want, html)
+		}
+	}
+}
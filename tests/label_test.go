@@ -0,0 +1,46 @@
+package form_test
+
+import (
+	"testing"
+
+	"github.com/tinywasm/fmt"
+	"github.com/tinywasm/form"
+)
+
+// labelHTML renders a one-field form whose label is title.
+func labelHTML(t *testing.T, title string, opts ...form.Option) string {
+	t.Helper()
+	f, err := form.New("app", &submitStruct{}, &testIDGen{}, opts...)
+	if err != nil {
+		t.Fatalf("form.New: %v", err)
+	}
+	f.Input("nombre").(interface{ SetTitle(string) }).SetTitle(title)
+	return f.String()
+}
+
+func TestLabel_TruncatesByRunes(t *testing.T) {
+	cases := []struct {
+		name, title, want string
+		opts              []form.Option
+	}{
+		{"short accented label untouched", "Dirección", ">Dirección</label>", nil},
+		{"14 runes fit the default budget", "Teléfono móvil", ">Teléfono móvil</label>", nil},
+		{"cut lands on a rune boundary", "Teléfono móvil personal", ">Teléfono mó...</label>", nil},
+		{"theme budget", "Dirección postal", ">Direc...</label>",
+			[]form.Option{form.WithTheme(form.Theme{LabelChars: 8})}},
+		{"truncation off", "Dirección postal completa", ">Dirección postal completa</label>",
+			[]form.Option{form.WithTheme(form.Theme{LabelChars: form.NoTruncation})}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			html := labelHTML(t, c.title, c.opts...)
+			if !fmt.Contains(html, c.want) {
+				t.Errorf("expected %q in: %s", c.want, html)
+			}
+			// The full text always stays reachable in the tooltip.
+			if !fmt.Contains(html, "title='"+c.title+"'") {
+				t.Errorf("expected untruncated title %q in: %s", c.title, html)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package form_test
+
+import (
+	"testing"
+
+	"github.com/tinywasm/dom"
+	"github.com/tinywasm/fmt"
+	"github.com/tinywasm/form"
+)
+
+// bootstrapLayout is the shape a Bootstrap host would write: its own wrapper
+// and feedback classes, plus a reactive is-invalid on the control.
+type bootstrapLayout struct{}
+
+func (bootstrapLayout) LayoutField(p form.FieldParts) *dom.Element {
+	root := dom.NewElement("div").Class("mb-3")
+	if p.Label != nil {
+		root.Child(p.Label)
+	}
+	for _, c := range p.Controls {
+		root.Child(c.BindClassFunc("is-invalid", p.Invalid))
+	}
+	return root.Child(p.Error.Class("invalid-feedback"))
+}
+
+func TestForm_WithLayout(t *testing.T) {
+	f, _ := form.New("app", &submitStruct{}, &testIDGen{}, form.WithLayout(bootstrapLayout{}))
+	html := f.String()
+
+	for _, want := range []string{"class='mb-3'", "invalid-feedback", "id='app.form.nombre'"} {
+		if !fmt.Contains(html, want) {
+			t.Errorf("Expected html to contain %q, got: %s", want, html)
+		}
+	}
+	if fmt.Contains(html, "is-invalid") {
+		t.Errorf("a valid field must not carry is-invalid, got: %s", html)
+	}
+}
+
+func TestForm_DefaultLayout_Explicit(t *testing.T) {
+	implicit, _ := form.New("app", &anatomyStruct{}, &testIDGen{})
+	explicit, _ := form.New("app", &anatomyStruct{}, &testIDGen{}, form.WithLayout(form.DefaultLayout{}))
+	if implicit.String() != explicit.String() {
+		t.Error("WithLayout(DefaultLayout{}) must render exactly what a form without it renders")
+	}
+}
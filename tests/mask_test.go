@@ -0,0 +1,111 @@
+package form_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tinywasm/form"
+	"github.com/tinywasm/input"
+	"github.com/tinywasm/model"
+)
+
+type payeeRecord struct {
+	Rut    string
+	Phone  string
+	Amount float64
+}
+
+func (m *payeeRecord) Schema() []model.Field {
+	return []model.Field{
+		{Name: "Rut", Type: input.Rut()},
+		{Name: "Phone", Type: input.Phone()},
+		{Name: "Amount", Type: input.Decimal()},
+	}
+}
+
+func (m *payeeRecord) Pointers() []any { return []any{&m.Rut, &m.Phone, &m.Amount} }
+
+func payeeForm(t *testing.T, data *payeeRecord) *form.Form {
+	t.Helper()
+	f, err := form.New("parent", data, &testIDGen{},
+		form.WithFormatter("Rut", form.RutMask()),
+		form.WithFormatter("Phone", form.PatternMask("(###) ###-####")),
+		form.WithFormatter("Amount", form.CurrencyMask("$ ", ".", ",", 2)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func TestMask_ShowsFormattedStoresNormalised(t *testing.T) {
+	f := payeeForm(t, &payeeRecord{Rut: "12345678-5", Phone: "5551234567", Amount: 1234.5})
+	html := f.String()
+	for _, want := range []string{
+		"value='12.345.678-5'",
+		"value='(555) 123-4567'",
+		"value='$ 1.234,5'",
+		"type='text' inputmode='decimal'",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("render missing %q:\n%s", want, html)
+		}
+	}
+	if got := displayed(f, "Rut"); got != "12345678-5" {
+		t.Errorf("value signal holds %q, want the normalised RUT", got)
+	}
+
+	f.SetValues("Amount", "99.9")
+	if !strings.Contains(f.String(), "value='$ 99,9'") {
+		t.Errorf("SetValues not re-formatted:\n%s", f.String())
+	}
+	dst := &payeeRecord{}
+	if err := f.SyncValues(dst); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Rut != "12345678-5" || dst.Phone != "5551234567" || dst.Amount != 99.9 {
+		t.Errorf("SyncValues wrote %+v", dst)
+	}
+	if err := f.Validate(); err != nil {
+		t.Errorf("normalised values should validate: %v", err)
+	}
+}
+
+func TestMask_BuiltIns(t *testing.T) {
+	cases := []struct {
+		fm              form.Formatter
+		typed, value    string
+		formattedAsType string
+	}{
+		{form.RutMask(), "12.345.678-K", "12345678-k", "12.345.678-k"},
+		{form.RutMask(), "1", "1", "1"},
+		{form.RutMask(), "1234", "123-4", "123-4"},
+		{form.PatternMask("(###) ###-####"), "555 12", "55512", "(555) 12"},
+		{form.PatternMask("(###) ###-####"), "555-123-4567-99", "5551234567", "(555) 123-4567"},
+		{form.CurrencyMask("$ ", ".", ",", 2), "$ 1.234,567", "1234.56", "$ 1.234,56"},
+		{form.CurrencyMask("$ ", ".", ",", 2), "1234,", "1234.", "$ 1.234,"},
+		{form.CurrencyMask("$ ", ".", ",", 2), "-", "-", "-$ "},
+		{form.CurrencyMask("$ ", ".", ",", 2), "-$ 007", "-7", "-$ 7"},
+		{form.CurrencyMask("", ",", ".", 0), "1,234.5", "12345", "12,345"},
+		// pasted or autofilled with a '.' point: not a 100x amount
+		{form.CurrencyMask("$ ", ".", ",", 2), "1234.50", "1234.50", "$ 1.234,50"},
+		{form.CurrencyMask("$ ", ".", ",", 2), "1.234.5", "1234.5", "$ 1.234,5"},
+		{form.CurrencyMask("$ ", ".", ",", 2), "1.234", "1234", "$ 1.234"},
+		{form.CurrencyMask("$ ", " ", ",", 2), "$ 99.9", "99.9", "$ 99,9"},
+	}
+	for _, c := range cases {
+		v := c.fm.Normalize(c.typed)
+		if v != c.value {
+			t.Errorf("Normalize(%q) = %q, want %q", c.typed, v, c.value)
+		}
+		shown := c.fm.Format(v)
+		if shown != c.formattedAsType {
+			t.Errorf("Format(%q) = %q, want %q", v, shown, c.formattedAsType)
+		}
+		if again := c.fm.Normalize(shown); again != v {
+			t.Errorf("Normalize(Format(%q)) = %q, not idempotent", v, again)
+		}
+		if again := c.fm.Normalize(v); again != v {
+			t.Errorf("Normalize(%q) = %q, not idempotent over its own output", v, again)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package form_test
+
+import (
+	"testing"
+
+	"github.com/tinywasm/form"
+	"github.com/tinywasm/input"
+	"github.com/tinywasm/model"
+)
+
+// nullFloat is a sql.NullFloat64-style wrapper satisfying form.Nullable.
+type nullFloat struct {
+	Float float64
+	Valid bool
+}
+
+func (n *nullFloat) Target() any    { return &n.Float }
+func (n *nullFloat) IsNull() bool   { return !n.Valid }
+func (n *nullFloat) SetNull(b bool) { n.Valid = !b }
+
+type nullRecord struct {
+	Age   *int64
+	Nick  *string
+	Score nullFloat
+}
+
+func (m *nullRecord) Schema() []model.Field {
+	return []model.Field{
+		{Name: "Age", Type: input.Number()},
+		{Name: "Nick", Type: input.Text()},
+		{Name: "Score", Type: input.Decimal()},
+	}
+}
+
+func (m *nullRecord) Pointers() []any { return []any{&m.Age, &m.Nick, &m.Score} }
+
+func ptrTo[T any](v T) *T { return &v }
+
+func TestNull_EmptyBindsAsNull(t *testing.T) {
+	f, _ := form.New("parent", &nullRecord{}, &testIDGen{})
+	for _, name := range []string{"Age", "Nick", "Score"} {
+		if !f.IsNull(name) {
+			t.Errorf("%s: IsNull = false on a NULL record", name)
+		}
+	}
+
+	dst := &nullRecord{Age: ptrTo(int64(3)), Nick: ptrTo("x"), Score: nullFloat{1, true}}
+	if err := f.SyncValues(dst); err != nil {
+		t.Fatalf("SyncValues: %v", err)
+	}
+	if dst.Age != nil || dst.Nick != nil || dst.Score.Valid {
+		t.Errorf("empty fields must sync as NULL, got Age=%v Nick=%v Score=%+v", dst.Age, dst.Nick, dst.Score)
+	}
+
+	f.SetValues("Age", "0")
+	f.SetValues("Score", "2.5")
+	if err := f.SyncValues(dst); err != nil {
+		t.Fatalf("SyncValues: %v", err)
+	}
+	if dst.Age == nil || *dst.Age != 0 || !dst.Score.Valid || dst.Score.Float != 2.5 {
+		t.Errorf("0 and 2.5 must sync as values, got Age=%v Score=%+v", dst.Age, dst.Score)
+	}
+	if f.IsNull("Age") {
+		t.Error("Age holding \"0\" reported as NULL")
+	}
+
+	// A value that does not convert leaves the field as it was.
+	f.SetValues("Age", "x")
+	if err := f.SyncValues(dst); err == nil || dst.Age == nil || *dst.Age != 0 {
+		t.Errorf("unconvertible value: err=%v Age=%v, want error and Age untouched", err, dst.Age)
+	}
+}
+
+func TestNull_LoadValuesAndDirty(t *testing.T) {
+	f, _ := form.New("parent", &nullRecord{}, &testIDGen{})
+
+	// NULL loads as empty and is pristine: "never set".
+	if err := f.LoadValues(&nullRecord{Nick: ptrTo("")}); err != nil {
+		t.Fatalf("LoadValues: %v", err)
+	}
+	if f.IsDirty() {
+		t.Error("freshly loaded record is dirty")
+	}
+	if f.IsNull("Nick") {
+		t.Error("a loaded non-NULL empty string reported as NULL")
+	}
+	dst := &nullRecord{}
+	f.SyncValues(dst)
+	if dst.Nick == nil || *dst.Nick != "" {
+		t.Errorf("untouched empty string synced as %v, want \"\"", dst.Nick)
+	}
+
+	// "" -> NULL is a change the text alone cannot show.
+	f.SetNull("Nick")
+	if !f.IsNull("Nick") || !f.IsDirty() {
+		t.Errorf("after SetNull: IsNull=%v IsDirty=%v, want true/true", f.IsNull("Nick"), f.IsDirty())
+	}
+	f.SyncValues(dst)
+	if dst.Nick != nil {
+		t.Errorf("Nick = %q after SetNull, want NULL", *dst.Nick)
+	}
+
+	// A value cleared by the user is "cleared": dirty, and NULL on sync.
+	f.LoadValues(&nullRecord{Age: ptrTo(int64(40))})
+	f.SetValues("Age", "")
+	if !f.IsDirty() || !f.IsNull("Age") {
+		t.Errorf("cleared Age: IsDirty=%v IsNull=%v, want true/true", f.IsDirty(), f.IsNull("Age"))
+	}
+	f.MarkPristine()
+	if f.IsDirty() {
+		t.Error("MarkPristine must capture the NULL state too")
+	}
+}
+
+func TestNull_SnapshotKeepsNullState(t *testing.T) {
+	f, _ := form.New("parent", &nullRecord{}, &testIDGen{})
+	f.LoadValues(&nullRecord{Nick: ptrTo("")})
+	f.SetNull("Nick")
+	snap := f.Snapshot()
+
+	g, _ := form.New("parent", &nullRecord{}, &testIDGen{})
+	g.LoadValues(&nullRecord{Nick: ptrTo("")})
+	if err := g.Restore(snap); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if !g.IsNull("Nick") || !g.IsDirty() {
+		t.Errorf("restored: IsNull=%v IsDirty=%v, want true/true", g.IsNull("Nick"), g.IsDirty())
+	}
+}
@@ -0,0 +1,189 @@
+package form_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tinywasm/fmt"
+	"github.com/tinywasm/form"
+	"github.com/tinywasm/input"
+	"github.com/tinywasm/model"
+)
+
+type placeRecord struct {
+	Country, Region, City string
+}
+
+func (m *placeRecord) Schema() []model.Field {
+	return []model.Field{
+		{Name: "Country", Type: input.Select()},
+		{Name: "Region", Type: input.Select()},
+		{Name: "City", Type: input.Select()},
+	}
+}
+
+func (m *placeRecord) Pointers() []any { return []any{&m.Country, &m.Region, &m.City} }
+
+var geo = map[string][]fmt.KeyValue{
+	"CL":  {{Key: "RM", Value: "Metropolitana"}, {Key: "VA", Value: "Valparaíso"}},
+	"AR":  {{Key: "BA", Value: "Buenos Aires"}},
+	"RM":  {{Key: "SCL", Value: "Santiago"}},
+	"VA":  {{Key: "VAP", Value: "Valparaíso"}, {Key: "VIN", Value: "Viña del Mar"}},
+	"BA":  {{Key: "LP", Value: "La Plata"}},
+	"SCL": nil,
+}
+
+// syncLoader answers from geo immediately and counts its calls.
+func syncLoader(calls *int) form.OptionLoader {
+	return func(parent string, done func([]fmt.KeyValue, error)) {
+		*calls++
+		done(geo[parent], nil)
+	}
+}
+
+func TestOptions_CascadeClearsInvalidValues(t *testing.T) {
+	f, _ := form.New("parent", &placeRecord{Country: "CL", Region: "VA", City: "VIN"}, &testIDGen{})
+	f.SetOptions("Country", fmt.KeyValue{Key: "CL", Value: "Chile"}, fmt.KeyValue{Key: "AR", Value: "Argentina"})
+	var regionCalls, cityCalls int
+	f.LoadOptions("Region", "Country", syncLoader(&regionCalls)).
+		LoadOptions("City", "Region", syncLoader(&cityCalls))
+
+	html := f.String()
+	for _, want := range []string{"Valparaíso", "Viña del Mar", "value='VIN' selected=''"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("initial render missing %q:\n%s", want, html)
+		}
+	}
+
+	// Changing the country: VA is no region of AR, so Region clears, and
+	// with it City — and City's options empty out.
+	f.SetValues("Country", "AR")
+	dst := &placeRecord{}
+	f.SyncValues(dst)
+	if dst.Region != "" || dst.City != "" {
+		t.Errorf("after AR: Region=%q City=%q, want both cleared", dst.Region, dst.City)
+	}
+	html = f.String()
+	if !strings.Contains(html, "Buenos Aires") || strings.Contains(html, "Viña del Mar") {
+		t.Errorf("options not re-rendered for AR:\n%s", html)
+	}
+
+	f.SetValues("Region", "BA")
+	if !strings.Contains(f.String(), "La Plata") {
+		t.Error("City options not loaded for BA")
+	}
+}
+
+func TestOptions_LoadingStateAndStaleResults(t *testing.T) {
+	f, _ := form.New("parent", &placeRecord{}, &testIDGen{})
+	var pending []func([]fmt.KeyValue, error)
+	f.LoadOptions("Region", "Country", func(_ string, done func([]fmt.KeyValue, error)) {
+		pending = append(pending, done)
+	})
+	if len(pending) != 0 {
+		t.Fatal("an empty parent must not call the loader")
+	}
+
+	f.SetValues("Country", "CL")
+	f.SetValues("Country", "AR")
+	html := f.String()
+	if !strings.Contains(html, "aria-busy='true'") || !strings.Contains(html, "disabled") {
+		t.Errorf("Region not busy/disabled while loading:\n%s", html)
+	}
+
+	pending[1](geo["AR"], nil)
+	pending[0](geo["CL"], nil) // the CL answer arrives late: ignored
+	html = f.String()
+	if !strings.Contains(html, "Buenos Aires") || strings.Contains(html, "Metropolitana") {
+		t.Errorf("stale options applied:\n%s", html)
+	}
+	if strings.Contains(html, "aria-busy='true'") {
+		t.Error("Region still busy after its load finished")
+	}
+}
+
+func TestOptions_LoadValuesReloads(t *testing.T) {
+	f, _ := form.New("parent", &placeRecord{}, &testIDGen{})
+	var calls int
+	f.LoadOptions("Region", "Country", syncLoader(&calls))
+
+	f.LoadValues(&placeRecord{Country: "CL", Region: "RM"})
+	dst := &placeRecord{}
+	f.SyncValues(dst)
+	if dst.Region != "RM" {
+		t.Errorf("Region = %q, want RM kept (valid for CL)", dst.Region)
+	}
+	if !strings.Contains(f.String(), "Metropolitana") {
+		t.Error("Region options not loaded for the loaded country")
+	}
+}
+
+func TestOptions_ResetClearsAndSupersedes(t *testing.T) {
+	f, _ := form.New("parent", &placeRecord{}, &testIDGen{})
+	var pending []func([]fmt.KeyValue, error)
+	f.LoadOptions("Region", "Country", func(_ string, done func([]fmt.KeyValue, error)) {
+		pending = append(pending, done)
+	})
+
+	f.SetValues("Country", "CL")
+	pending[0](geo["CL"], nil)
+	f.SetValues("Country", "AR")
+	f.Reset()
+	pending[1](geo["AR"], nil) // answers for the parent the reset dropped
+	html := f.String()
+	if strings.Contains(html, "Metropolitana") || strings.Contains(html, "Buenos Aires") {
+		t.Errorf("options survived the reset:\n%s", html)
+	}
+	if strings.Contains(html, "aria-busy='true'") {
+		t.Error("Region still busy after the reset")
+	}
+}
+
+func TestOptions_UnchangedParentDoesNotReload(t *testing.T) {
+	f, _ := form.New("parent", &placeRecord{}, &testIDGen{})
+	var calls int
+	f.LoadOptions("Region", "Country", syncLoader(&calls))
+
+	f.SetValues("Country", "CL")
+	f.SetValues("Country", "CL") // re-set, or committed again on a blur
+	if calls != 1 {
+		t.Errorf("loader ran %d times for one parent value, want 1", calls)
+	}
+	f.SetValues("Country", "AR")
+	if calls != 2 {
+		t.Errorf("loader ran %d times after a new parent, want 2", calls)
+	}
+}
+
+type cityRecord struct{ Country, City string }
+
+func (m *cityRecord) Schema() []model.Field {
+	return []model.Field{
+		{Name: "Country", Type: input.Select()},
+		{Name: "City", Type: input.Datalist()},
+	}
+}
+
+func (m *cityRecord) Pointers() []any { return []any{&m.Country, &m.City} }
+
+func TestOptions_DatalistDisabledWhileLoading(t *testing.T) {
+	f, _ := form.New("parent", &cityRecord{}, &testIDGen{})
+	var pending []func([]fmt.KeyValue, error)
+	f.LoadOptions("City", "Country", func(_ string, done func([]fmt.KeyValue, error)) {
+		pending = append(pending, done)
+	})
+	box := func() string {
+		html := f.String()
+		start := strings.Index(html, "list='")
+		return html[strings.LastIndex(html[:start], "<input"):strings.Index(html[start:], ">")+start]
+	}
+
+	f.SetValues("Country", "CL")
+	if b := box(); !strings.Contains(b, "aria-busy='true'") || !strings.Contains(b, "disabled") {
+		t.Errorf("City not busy/disabled while loading: %s", b)
+	}
+	pending[0](geo["RM"], nil)
+	if b := box(); strings.Contains(b, "aria-busy='true'") || strings.Contains(b, "disabled") {
+		t.Errorf("City still busy/disabled after its load finished: %s", b)
+	}
+}
@@ -0,0 +1,191 @@
+package form_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tinywasm/fmt"
+	"github.com/tinywasm/form"
+	"github.com/tinywasm/input"
+	"github.com/tinywasm/model"
+)
+
+// invoiceRecord references a customer by its integer id, looked up through
+// invoiceLookup (set per test, as a generated schema's would be at init).
+type invoiceRecord struct {
+	CustomerID int64
+}
+
+var invoiceLookup form.Lookup
+
+func (m *invoiceRecord) Schema() []model.Field {
+	return []model.Field{{Name: "CustomerID", Type: form.Reference(invoiceLookup)}}
+}
+
+func (m *invoiceRecord) Pointers() []any { return []any{&m.CustomerID} }
+
+type newCustomer struct{ Name string }
+
+func (m *newCustomer) Schema() []model.Field {
+	return []model.Field{{Name: "Name", Type: input.Text(), NotNull: true}}
+}
+
+func (m *newCustomer) Pointers() []any { return []any{&m.Name} }
+
+// customerLookup serves the customer table from memory and counts Label calls.
+type customerLookup struct {
+	rows   map[string]string
+	labels int
+}
+
+func (l *customerLookup) Search(q string, done func([]fmt.KeyValue)) {
+	var out []fmt.KeyValue
+	for id, name := range l.rows {
+		if strings.Contains(strings.ToLower(name), strings.ToLower(q)) {
+			out = append(out, fmt.KeyValue{Key: id, Value: name})
+		}
+	}
+	done(out)
+}
+
+func (l *customerLookup) Label(id string, done func(string, error)) {
+	l.labels++
+	if name, ok := l.rows[id]; ok {
+		done(name, nil)
+		return
+	}
+	done("", fmt.Err("customer", id, "not found"))
+}
+
+// creatingLookup adds record creation; Create answers later, through pending.
+type creatingLookup struct {
+	customerLookup
+	pending func(id, label string, err error)
+	created *newCustomer
+}
+
+func (l *creatingLookup) NewRecord() model.Fielder { return &newCustomer{} }
+
+func (l *creatingLookup) Create(data model.Fielder, done func(id, label string, err error)) {
+	l.created = data.(*newCustomer)
+	l.pending = done
+}
+
+func TestReference_LoadValuesShowsLabel(t *testing.T) {
+	lookup := &customerLookup{rows: map[string]string{"17": "Acme Corp", "42": "Globex"}}
+	invoiceLookup = lookup
+	f, err := form.New("parent", &invoiceRecord{}, &testIDGen{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.LoadValues(&invoiceRecord{CustomerID: 42}); err != nil {
+		t.Fatal(err)
+	}
+	html := f.String()
+	if !strings.Contains(html, "value='Globex'") || !strings.Contains(html, "value='42'") {
+		t.Errorf("loaded reference should show Globex over the id 42:\n%s", html)
+	}
+
+	// Already known: no second round trip.
+	calls := lookup.labels
+	f.SetValues("CustomerID", "42")
+	if lookup.labels != calls {
+		t.Errorf("Label called again for a known id")
+	}
+
+	// An unknown id keeps showing the id itself.
+	f.SetValues("CustomerID", "99")
+	if !strings.Contains(f.String(), "value='99'") {
+		t.Errorf("unresolved id should show as is:\n%s", f.String())
+	}
+
+	dst := &invoiceRecord{}
+	if err := f.SyncValues(dst); err != nil {
+		t.Fatal(err)
+	}
+	if dst.CustomerID != 99 {
+		t.Errorf("SyncValues wrote %d, want 99", dst.CustomerID)
+	}
+}
+
+func TestReference_NoCreateWithoutCreator(t *testing.T) {
+	invoiceLookup = &customerLookup{}
+	f, _ := form.New("parent", &invoiceRecord{}, &testIDGen{})
+	if strings.Contains(f.String(), "tw-field__create") {
+		t.Error("a Lookup that is not a Creator must not offer New")
+	}
+	if _, err := f.CreateReference("CustomerID"); err == nil {
+		t.Error("CreateReference should fail without a Creator")
+	}
+}
+
+func TestReference_CreateNewPicksCreatedRecord(t *testing.T) {
+	lookup := &creatingLookup{customerLookup: customerLookup{rows: map[string]string{}}}
+	invoiceLookup = lookup
+	f, _ := form.New("parent", &invoiceRecord{}, &testIDGen{})
+	changes := 0
+	f.OnFieldChange(func() { changes++ })
+
+	if html := f.String(); !strings.Contains(html, "aria-expanded='false'>New</button>") {
+		t.Fatalf("New button missing:\n%s", html)
+	}
+	sub, err := f.CreateReference("CustomerID")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := f.CreateReference("CustomerID"); again != sub {
+		t.Error("a second CreateReference should return the open form")
+	}
+	html := f.String()
+	if strings.Count(html, "<form") != 1 || !strings.Contains(html, "role='form'") {
+		t.Errorf("nested form must not render a second <form>:\n%s", html)
+	}
+	if !strings.Contains(html, ".create.cancel'") {
+		t.Errorf("Cancel needs an id to close the nested form's focus trap:\n%s", html)
+	}
+
+	sub.SetValues("Name", "Initech")
+	if err := sub.Submit(); err != nil {
+		t.Fatal(err)
+	}
+	if lookup.created == nil || lookup.created.Name != "Initech" {
+		t.Fatalf("Create got %+v", lookup.created)
+	}
+	lookup.pending("7", "Initech", nil)
+
+	dst := &invoiceRecord{}
+	f.SyncValues(dst)
+	if dst.CustomerID != 7 {
+		t.Errorf("created id not picked: %d", dst.CustomerID)
+	}
+	html = f.String()
+	if !strings.Contains(html, "value='Initech'") || strings.Contains(html, "role='form'") {
+		t.Errorf("after create: want label shown and nested form closed:\n%s", html)
+	}
+	if changes != 1 {
+		t.Errorf("OnFieldChange fired %d times, want 1", changes)
+	}
+}
+
+func TestReference_CreateFailureKeepsFormOpen(t *testing.T) {
+	lookup := &creatingLookup{customerLookup: customerLookup{rows: map[string]string{}}}
+	invoiceLookup = lookup
+	f, _ := form.New("parent", &invoiceRecord{}, &testIDGen{})
+	sub, _ := f.CreateReference("CustomerID")
+	sub.SetValues("Name", "Initech")
+	sub.Submit()
+	lookup.pending("", "", fmt.Err("duplicate"))
+	if !strings.Contains(f.String(), "role='form'") {
+		t.Error("a failed create should keep the nested form open")
+	}
+
+	// Cancelling while a create is in flight: its late answer is ignored.
+	sub.Submit()
+	f.CancelReference("CustomerID")
+	lookup.pending("8", "Initech", nil)
+	dst := &invoiceRecord{}
+	f.SyncValues(dst)
+	if dst.CustomerID != 0 {
+		t.Errorf("a cancelled create must not pick its record, got %d", dst.CustomerID)
+	}
+}
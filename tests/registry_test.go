@@ -0,0 +1,51 @@
+package form_test
+
+import (
+	"testing"
+
+	"github.com/tinywasm/form"
+)
+
+// TestRegistry_LookupAndDispose covers the per-record lifecycle of a
+// long-lived SPA: build, find, dispose — and the registry forgets it.
+func TestRegistry_LookupAndDispose(t *testing.T) {
+	a, _ := form.New("registry-parent", &WidgetsModel{Name: "A"}, &testIDGen{})
+	b, _ := form.New("registry-parent", &submitStruct{Nombre: "B"}, &testIDGen{})
+
+	if got := form.FormByID(a.GetID()); got != a {
+		t.Errorf("FormByID(%q) = %p, want %p", a.GetID(), got, a)
+	}
+	if got := form.FormsByParent("registry-parent"); len(got) != 2 || got[0] != a || got[1] != b {
+		t.Errorf("FormsByParent = %v, want [a b]", got)
+	}
+
+	// Rebuilding under the same id without disposing: newest wins.
+	a2, _ := form.New("registry-parent", &WidgetsModel{Name: "A2"}, &testIDGen{})
+	if got := form.FormByID(a.GetID()); got != a2 {
+		t.Error("FormByID should return the most recently built form for a shared id")
+	}
+
+	before := len(form.Forms())
+	a.Dispose()
+	a.Dispose() // second call is a no-op
+	if n := len(form.Forms()); n != before-1 {
+		t.Errorf("len(Forms()) after Dispose = %d, want %d", n, before-1)
+	}
+	for _, f := range form.Forms() {
+		if f == a {
+			t.Fatal("disposed form is still registered")
+		}
+	}
+	if len(a.Inputs) != 0 {
+		t.Error("Dispose should drop the form's inputs")
+	}
+
+	b.Dispose()
+	a2.Dispose()
+	if got := form.FormsByParent("registry-parent"); len(got) != 0 {
+		t.Errorf("FormsByParent after disposing all = %d forms, want 0", len(got))
+	}
+	if form.FormByID(a.GetID()) != nil {
+		t.Error("FormByID should return nil once every form with that id is disposed")
+	}
+}
@@ -0,0 +1,104 @@
+package form_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tinywasm/form"
+	"github.com/tinywasm/input"
+	"github.com/tinywasm/model"
+)
+
+type productRecord struct {
+	Code  string
+	Name  string
+	Stock int64
+	Size  string
+}
+
+func (m *productRecord) Schema() []model.Field {
+	return []model.Field{
+		{Name: "Code", Type: input.Text(), DB: &model.FieldDB{Unique: true}},
+		{Name: "Name", Type: input.Text(), Permitted: model.Permitted{Maximum: 10}},
+		{Name: "Stock", Type: input.Number()},
+		{Name: "Size", Type: input.Text()},
+	}
+}
+
+func (m *productRecord) Pointers() []any { return []any{&m.Code, &m.Name, &m.Stock, &m.Size} }
+
+// takenCodes reports codes owned by a record other than the one validated.
+type takenCodes map[string]string
+
+func (t takenCodes) Taken(field, value string, record model.Fielder) (bool, error) {
+	owner, ok := t[value]
+	return ok && owner != record.(*productRecord).Name, nil
+}
+
+func productForm(t *testing.T, data *productRecord) *form.Form {
+	t.Helper()
+	f, err := form.New("parent", data, &testIDGen{},
+		form.WithConstraints("Stock", form.Constraints{Min: "0", Max: "500"}),
+		form.WithConstraints("Code", form.Constraints{Pattern: "[A-Z]{2}[0-9]{3}"}),
+		form.WithConstraints("Size", form.Constraints{Enum: []string{"small", "large"}}),
+		form.WithUniqueChecker(takenCodes{"AB123": "Widget"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func TestRules_HTMLAttributes(t *testing.T) {
+	html := productForm(t, &productRecord{}).String()
+	for _, want := range []string{
+		"min='0' max='500'",
+		"pattern='[A-Z]{2}[0-9]{3}'",
+		"maxlength='10'",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("render missing %q:\n%s", want, html)
+		}
+	}
+}
+
+func TestRules_ValidateData(t *testing.T) {
+	f := productForm(t, &productRecord{})
+	cases := []struct {
+		name string
+		rec  productRecord
+		ok   bool
+	}{
+		{"valid", productRecord{Code: "CD456", Name: "Gadget", Stock: 10, Size: "small"}, true},
+		{"stock over max", productRecord{Code: "CD456", Name: "Gadget", Stock: 501, Size: "small"}, false},
+		{"stock under min", productRecord{Code: "CD456", Name: "Gadget", Stock: -1, Size: "small"}, false},
+		{"name too long", productRecord{Code: "CD456", Name: "Gadgetronic", Stock: 1, Size: "small"}, false},
+		{"pattern substring only", productRecord{Code: "xCD456", Name: "Gadget", Stock: 1, Size: "small"}, false},
+		{"enum", productRecord{Code: "CD456", Name: "Gadget", Stock: 1, Size: "medium"}, false},
+		{"unique taken", productRecord{Code: "AB123", Name: "Gadget", Stock: 1, Size: "small"}, false},
+		{"unique own value", productRecord{Code: "AB123", Name: "Widget", Stock: 1, Size: "small"}, true},
+		{"stock at bounds", productRecord{Code: "CD456", Name: "Gadget", Stock: 500, Size: "small"}, true},
+	}
+	for _, c := range cases {
+		rec := c.rec
+		err := f.ValidateData(model.ActionCreate, &rec)
+		if (err == nil) != c.ok {
+			t.Errorf("%s: ValidateData = %v, want ok=%v", c.name, err, c.ok)
+		}
+	}
+}
+
+func TestRules_ValidateAndLive(t *testing.T) {
+	f := productForm(t, &productRecord{Code: "CD456", Name: "Gadget", Stock: 5, Size: "large"})
+	if err := f.Validate(); err != nil {
+		t.Fatalf("valid record rejected: %v", err)
+	}
+	f.SetValues("Stock", "900")
+	if err := f.Validate(); err == nil {
+		t.Error("Validate should enforce Max")
+	}
+	f.SetValues("Stock", "5")
+	f.SetValues("Code", "AB123")
+	if err := f.Validate(); err == nil || !strings.Contains(err.Error(), "exists") {
+		t.Errorf("Validate should check uniqueness, got %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package form_test
+
+import (
+	"testing"
+
+	"github.com/tinywasm/form"
+)
+
+// TestSnapshotRestore covers the SPA round trip: snapshot a half-edited form,
+// throw it away, rebuild it on return and restore — the user finds values,
+// dirty state, lock and wizard step exactly as they left them.
+func TestSnapshotRestore(t *testing.T) {
+	f, err := form.New("parent-id", &WidgetsModel{Name: "Original", Price: 100}, &testIDGen{})
+	if err != nil {
+		t.Fatalf("unexpected error creating form: %v", err)
+	}
+	// A value carrying the token separator must survive untouched.
+	f.SetValues("Name", "a:b 3:c")
+	f.SetLocked(true)
+	f.SetStep(2)
+	f.Focus()
+	snap := f.Snapshot()
+
+	g, _ := form.New("parent-id", &WidgetsModel{}, &testIDGen{})
+	if err := g.Restore(snap); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+
+	target := &WidgetsModel{}
+	g.SyncValues(target)
+	if target.Name != "a:b 3:c" || target.Price != 100 {
+		t.Errorf("restored values = %q/%d, want %q/100", target.Name, target.Price, "a:b 3:c")
+	}
+	if !g.IsDirty() {
+		t.Error("expected the restored baseline to keep the form dirty")
+	}
+	if g.Step() != 2 {
+		t.Errorf("Step() = %d, want 2", g.Step())
+	}
+	if g.FocusedFieldID() != f.FocusedFieldID() {
+		t.Errorf("FocusedFieldID() = %q, want %q", g.FocusedFieldID(), f.FocusedFieldID())
+	}
+	if g.Snapshot() != snap {
+		t.Error("expected a restored form to snapshot identically")
+	}
+}
+
+func TestRestore_RejectsMismatch(t *testing.T) {
+	f, _ := form.New("parent-id", &WidgetsModel{Name: "Keep"}, &testIDGen{})
+	before := f.Snapshot()
+
+	other, _ := form.New("app", &submitStruct{Nombre: "x"}, &testIDGen{})
+	if err := f.Restore(other.Snapshot()); err == nil {
+		t.Error("expected Restore to reject a snapshot with a different field set")
+	}
+	if err := f.Restore("garbage"); err == nil {
+		t.Error("expected Restore to reject a malformed snapshot")
+	}
+	if err := f.Restore(before[:len(before)-1]); err == nil {
+		t.Error("expected Restore to reject a truncated snapshot")
+	}
+	if f.Snapshot() != before {
+		t.Error("a rejected Restore must leave the form untouched")
+	}
+}
@@ -0,0 +1,53 @@
+package form_test
+
+import (
+	"testing"
+
+	"github.com/tinywasm/dom"
+	"github.com/tinywasm/fmt"
+)
+
+func TestModified_FollowsBaseline(t *testing.T) {
+	f := productForm(t, &productRecord{Code: "CD456", Name: "Gadget", Stock: 5, Size: "small"})
+	size := f.Modified("Size")
+	if size == nil || size.Get() {
+		t.Fatal("a fresh form is unmodified")
+	}
+	f.SetValues("Size", "large")
+	if !size.Get() || f.Modified("Name").Get() {
+		t.Error("only Size was modified")
+	}
+	f.MarkPristine()
+	if size.Get() {
+		t.Error("MarkPristine makes the current value the baseline")
+	}
+	f.SetValues("Size", "small")
+	f.LoadValues(&productRecord{Code: "CD456", Name: "Gadget", Stock: 5, Size: "small"})
+	if size.Get() {
+		t.Error("a loaded record is unmodified")
+	}
+	if f.Modified("nope") != nil {
+		t.Error("unknown field: nil signal")
+	}
+}
+
+func TestState_Aggregates(t *testing.T) {
+	f := productForm(t, &productRecord{Name: "Gadget"})
+	progress := dom.DeriveString(func() string {
+		s := f.State()
+		return fmt.Convert(s.Filled).String() + "/" + fmt.Convert(s.Total).String()
+	})
+	if progress.Get() != "2/4" { // Name, and Stock's 0
+		t.Fatalf("progress = %q", progress.Get())
+	}
+	f.SetValues("Code", "CD456")
+	if progress.Get() != "3/4" {
+		t.Errorf("State should be reactive: %q", progress.Get())
+	}
+
+	f.ValidateFields("Size")
+	s := f.State()
+	if s.Invalid != 1 || s.Modified != 1 || s.Touched != 0 || s.Focused != "" {
+		t.Errorf("State = %+v", s)
+	}
+}
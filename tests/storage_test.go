@@ -0,0 +1,224 @@
+package form_test
+
+import (
+	"testing"
+
+	"github.com/tinywasm/form"
+	"github.com/tinywasm/input"
+	"github.com/tinywasm/model"
+)
+
+// narrowRecord covers the Go storage types beyond int64/float64/string/bool.
+type narrowRecord struct {
+	Count int32
+	Big   uint64
+	Ratio float32
+	Note  []byte
+}
+
+func (m *narrowRecord) Schema() []model.Field {
+	return []model.Field{
+		{Name: "Count", Type: input.Number()},
+		{Name: "Big", Type: input.Number()},
+		{Name: "Ratio", Type: input.Decimal()},
+		{Name: "Note", Type: input.Textarea()},
+	}
+}
+
+func (m *narrowRecord) Pointers() []any { return []any{&m.Count, &m.Big, &m.Ratio, &m.Note} }
+
+// displayed is the text a field shows (the input's own copy, kept in step
+// with its value signal).
+func displayed(f *form.Form, name string) string {
+	if g, ok := f.Input(name).(interface{ GetValues() []string }); ok {
+		if v := g.GetValues(); len(v) > 0 {
+			return v[0]
+		}
+	}
+	return ""
+}
+
+func TestStorage_NarrowTypesRoundTrip(t *testing.T) {
+	src := &narrowRecord{Count: -42, Big: 18446744073709551615, Ratio: 0.5, Note: []byte("hola")}
+	f, err := form.New("parent", src, &testIDGen{})
+	if err != nil {
+		t.Fatalf("form.New: %v", err)
+	}
+	for name, want := range map[string]string{"Count": "-42", "Big": "18446744073709551615", "Ratio": "0.5", "Note": "hola"} {
+		if got := displayed(f, name); got != want {
+			t.Errorf("%s displayed as %q, want %q", name, got, want)
+		}
+	}
+
+	dst := &narrowRecord{}
+	if err := f.SyncValues(dst); err != nil {
+		t.Fatalf("SyncValues: %v", err)
+	}
+	if dst.Count != src.Count || dst.Big != src.Big || dst.Ratio != src.Ratio || string(dst.Note) != "hola" {
+		t.Errorf("round trip = %+v, want %+v", *dst, *src)
+	}
+
+	// Clearing zeroes every type.
+	for _, name := range []string{"Count", "Big", "Ratio", "Note"} {
+		f.SetValues(name, "")
+	}
+	if err := f.SyncValues(dst); err != nil {
+		t.Fatalf("SyncValues: %v", err)
+	}
+	if dst.Count != 0 || dst.Big != 0 || dst.Ratio != 0 || dst.Note != nil {
+		t.Errorf("after clear = %+v, want zero values", *dst)
+	}
+}
+
+func TestStorage_Base64RoundTripsArbitraryBytes(t *testing.T) {
+	raw := []byte{0xff, 0x00, 0xfe, 'a'} // not valid UTF-8
+	f, _ := form.New("parent", &narrowRecord{Note: raw}, &testIDGen{}, form.WithCodec("Note", form.Base64()))
+	if got := displayed(f, "Note"); got != "/wD+YQ==" {
+		t.Errorf("Note displayed as %q, want its base64", got)
+	}
+	dst := &narrowRecord{}
+	if err := f.SyncValues(dst); err != nil {
+		t.Fatalf("SyncValues: %v", err)
+	}
+	if string(dst.Note) != string(raw) {
+		t.Errorf("Note = %v, want %v", dst.Note, raw)
+	}
+
+	f.SetValues("Note", "not base64!")
+	dst.Note = raw
+	errs, ok := f.SyncValues(dst).(form.ConvertErrors)
+	if !ok || len(errs) != 1 || errs[0].Field != "Note" || string(dst.Note) != string(raw) {
+		t.Errorf("bad base64: SyncValues = %v, Note = %v; want a ConvertError, Note untouched", errs, dst.Note)
+	}
+}
+
+func TestStorage_RejectsOutOfRange(t *testing.T) {
+	f, _ := form.New("parent", &narrowRecord{}, &testIDGen{})
+	f.SetValues("Count", "2147483648") // int32 max + 1
+	f.SetValues("Big", "-1")           // would wrap to the uint64 max
+	dst := &narrowRecord{Count: 7, Big: 9}
+	errs, ok := f.SyncValues(dst).(form.ConvertErrors)
+	if !ok || len(errs) != 2 {
+		t.Fatalf("SyncValues = %v, want two ConvertErrors", errs)
+	}
+	if dst.Count != 7 || dst.Big != 9 {
+		t.Errorf("out-of-range values were written: %+v", *dst)
+	}
+}
+
+// eventRecord keeps dates and money in their database shapes.
+type eventRecord struct {
+	Day    int64  // unix seconds
+	At     string // RFC 3339
+	Amount int64  // cents
+}
+
+func (m *eventRecord) Schema() []model.Field {
+	return []model.Field{
+		{Name: "Day", Type: input.Date()},
+		{Name: "At", Type: input.Text()},
+		{Name: "Amount", Type: input.Decimal()},
+	}
+}
+
+func (m *eventRecord) Pointers() []any { return []any{&m.Day, &m.At, &m.Amount} }
+
+func newEventForm(t *testing.T, data *eventRecord) *form.Form {
+	t.Helper()
+	f, err := form.New("parent", data, &testIDGen{},
+		form.WithCodec("Day", form.UnixDate()),
+		form.WithCodec("At", form.RFC3339DateTime()),
+		form.WithCodec("Amount", form.ScaledDecimal(2)))
+	if err != nil {
+		t.Fatalf("form.New: %v", err)
+	}
+	return f
+}
+
+func TestStorage_CodecsRoundTrip(t *testing.T) {
+	src := &eventRecord{Day: 951782400, At: "2024-03-10T09:30:00-03:00", Amount: 123450}
+	f := newEventForm(t, src)
+
+	want := map[string]string{"Day": "2000-02-29", "At": "2024-03-10T12:30:00", "Amount": "1234.50"}
+	for name, w := range want {
+		if got := displayed(f, name); got != w {
+			t.Errorf("%s displayed as %q, want %q", name, got, w)
+		}
+	}
+
+	f.SetValues("Day", "1969-12-31")
+	f.SetValues("At", "2024-03-10T12:30")
+	f.SetValues("Amount", "0.05")
+	dst := &eventRecord{}
+	if err := f.SyncValues(dst); err != nil {
+		t.Fatalf("SyncValues: %v", err)
+	}
+	if dst.Day != -86400 || dst.At != "2024-03-10T12:30:00Z" || dst.Amount != 5 {
+		t.Errorf("stored = %+v, want {-86400 2024-03-10T12:30:00Z 5}", *dst)
+	}
+
+	// LoadValues goes through the same codecs.
+	if err := f.LoadValues(&eventRecord{Day: 0, Amount: -1999}); err != nil {
+		t.Fatalf("LoadValues: %v", err)
+	}
+	if displayed(f, "Day") != "1970-01-01" || displayed(f, "Amount") != "-19.99" || displayed(f, "At") != "" {
+		t.Errorf("loaded Day=%q Amount=%q At=%q", displayed(f, "Day"), displayed(f, "Amount"), displayed(f, "At"))
+	}
+}
+
+func TestStorage_CodecRejectsWithoutWriting(t *testing.T) {
+	f := newEventForm(t, &eventRecord{})
+	f.SetValues("Day", "2023-02-29") // not a leap year
+	f.SetValues("Amount", "1.005")   // more precision than cents: never rounded
+	dst := &eventRecord{Day: 1, Amount: 2}
+	errs, ok := f.SyncValues(dst).(form.ConvertErrors)
+	if !ok || len(errs) != 2 || errs[0].Field != "Day" || errs[1].Field != "Amount" {
+		t.Fatalf("SyncValues = %v, want ConvertErrors for Day and Amount", errs)
+	}
+	if dst.Day != 1 || dst.Amount != 2 {
+		t.Errorf("rejected values were written: %+v", *dst)
+	}
+
+	// Trailing zeros past the scale are not extra precision.
+	f.SetValues("Day", "")
+	f.SetValues("Amount", "7.500")
+	if err := f.SyncValues(dst); err != nil || dst.Amount != 750 {
+		t.Errorf("\"7.500\" = %d, %v; want 750", dst.Amount, err)
+	}
+}
+
+type birthdayRecord struct{ Born int64 }
+
+func (m *birthdayRecord) Schema() []model.Field {
+	return []model.Field{{Name: "Born", Type: input.Date()}}
+}
+
+func (m *birthdayRecord) Pointers() []any { return []any{&m.Born} }
+
+// ValidateData must hand the input its display text: a Date input rejects
+// "951782400" but accepts "2000-02-29".
+func TestStorage_ValidateDataUsesCodec(t *testing.T) {
+	f, _ := form.New("parent", &birthdayRecord{}, &testIDGen{}, form.WithCodec("Born", form.UnixDate()))
+	if err := f.ValidateData(model.ActionUpdate, &birthdayRecord{Born: 951782400}); err != nil {
+		t.Errorf("ValidateData: %v", err)
+	}
+}
+
+func TestBase64_Codec(t *testing.T) {
+	c := form.Base64()
+	for _, raw := range []string{"", "a", "ab", "abc", "hola", "\xff\x00\xfe"} {
+		enc, _ := c.FromStorage(raw)
+		back, err := c.ToStorage(enc)
+		if err != nil || back != raw {
+			t.Errorf("%q -> %q -> %q (%v)", raw, enc, back, err)
+		}
+	}
+	if enc, _ := c.FromStorage("hola"); enc != "aG9sYQ==" {
+		t.Errorf("hola encodes as %q", enc)
+	}
+	for _, bad := range []string{"abc", "a=bc", "ab=c", "a$cd", "aG9s=Q=="} {
+		if _, err := c.ToStorage(bad); err == nil {
+			t.Errorf("%q accepted", bad)
+		}
+	}
+}
@@ -22,14 +22,14 @@ func TestForm_SetClass(t *testing.T) {
 
 func TestForm_SetClass_Append(t *testing.T) {
 	form.SetGlobalClass("global-class")
-	defer form.SetGlobalClass("") // Reset global state
+	defer form.ResetDefaultTheme() // Reset global state
 
 	s := &submitStruct{}
 	f, _ := form.New("app", s, &testIDGen{})
 	f.SetClass("local-class")
 
 	html := f.String()
-	// New() uses globalClass as initial f.class. SetClass appends.
+	// New() uses the default theme's Form classes as initial f.class. SetClass appends.
 	// Initial f.class = "global-class"
 	// After SetClass("local-class"), f.class = "global-class local-class"
 	expected := "class='global-class local-class'"
@@ -37,3 +37,53 @@ func TestForm_SetClass_Append(t *testing.T) {
 		t.Errorf("Expected html to contain %q, got: %s", expected, html)
 	}
 }
+
+func TestForm_WithTheme(t *testing.T) {
+	theme := form.Theme{
+		Form:   "needs-validation",
+		Field:  "form-group",
+		Label:  "form-label",
+		Input:  "form-control",
+		Error:  "invalid-feedback",
+		Submit: "btn btn-primary",
+	}
+	f, _ := form.New("app", &submitStruct{}, &testIDGen{}, form.WithTheme(theme))
+	html := f.String()
+
+	// Theme classes are layered on top of the tw-* anatomy, never instead of it.
+	for _, want := range []string{
+		"class='needs-validation'",
+		"class='tw-field form-group'",
+		"class='tw-field__label form-label'",
+		"class='tw-field__input form-control'",
+		"class='tw-field__error invalid-feedback'",
+		"class='tw-field__submit btn btn-primary'",
+	} {
+		if !fmt.Contains(html, want) {
+			t.Errorf("Expected html to contain %q, got: %s", want, html)
+		}
+	}
+
+	// Scoped: a sibling form built without the option is untouched.
+	plain, _ := form.New("app", &submitStruct{}, &testIDGen{})
+	if fmt.Contains(plain.String(), "form-control") {
+		t.Error("a theme passed via WithTheme leaked into another form")
+	}
+}
+
+func TestForm_DefaultTheme_ExplicitReset(t *testing.T) {
+	form.SetDefaultTheme(form.Theme{Input: "app-input"})
+	themed, _ := form.New("app", &submitStruct{}, &testIDGen{})
+	if !fmt.Contains(themed.String(), "class='tw-field__input app-input'") {
+		t.Error("expected a form built under SetDefaultTheme to carry its classes")
+	}
+
+	form.ResetDefaultTheme()
+	plain, _ := form.New("app", &submitStruct{}, &testIDGen{})
+	if !fmt.Contains(themed.String(), "class='tw-field__input app-input'") {
+		t.Error("ResetDefaultTheme must not rewrite forms already built")
+	}
+	if fmt.Contains(plain.String(), "app-input") {
+		t.Error("expected ResetDefaultTheme to stop later forms from inheriting the default")
+	}
+}
@@ -0,0 +1,101 @@
+package form_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tinywasm/form"
+	"github.com/tinywasm/model"
+)
+
+// submittingNow reports whether f renders its submit button disabled — the
+// button's own tag, not any disabled field or class elsewhere in the form.
+func submittingNow(f *form.Form) bool {
+	html := f.String()
+	start := strings.Index(html, "id='"+f.GetID()+".submit'")
+	if start < 0 {
+		return false
+	}
+	start = strings.LastIndex(html[:start], "<button")
+	end := start + strings.Index(html[start:], ">")
+	return strings.Contains(html[start:end], "disabled")
+}
+
+func TestSubmitHooks_Order(t *testing.T) {
+	s := &submitStruct{}
+	f, _ := form.New("app", s, &testIDGen{})
+	var steps []string
+	var done func(error)
+	f.BeforeValidate(func() {
+		steps = append(steps, "normalise")
+		f.SetValues("nombre", "Ann") // would fail validation empty
+	}).BeforeSubmit(func(data model.Fielder, proceed func(bool)) {
+		steps = append(steps, "confirm:"+data.(*submitStruct).Nombre)
+		proceed(true)
+	}).OnSubmit(func(_ model.Fielder, d func(error)) {
+		steps = append(steps, "send")
+		done = d
+	}).AfterSuccess(func(model.Fielder) {
+		steps = append(steps, "success")
+	}).AfterError(func(err error) {
+		steps = append(steps, "error:"+err.Error())
+	})
+
+	if err := f.Submit(); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	done(errors.New("offline"))
+	if err := f.Submit(); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	done(nil)
+
+	want := "normalise confirm:Ann send error:offline normalise confirm:Ann send success"
+	if got := strings.Join(steps, " "); got != want {
+		t.Errorf("steps:\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestSubmitHooks_ConfirmDeclined(t *testing.T) {
+	f, _ := form.New("app", &submitStruct{Nombre: "Ann"}, &testIDGen{})
+	var proceed func(bool)
+	sent := false
+	f.BeforeSubmit(func(_ model.Fielder, p func(bool)) { proceed = p }).
+		OnSubmit(func(model.Fielder, func(error)) { sent = true })
+
+	f.Submit()
+	if !submittingNow(f) {
+		t.Error("submitting while the confirmation is pending")
+	}
+	proceed(false)
+	if sent || submittingNow(f) {
+		t.Errorf("declined: sent=%v submitting=%v", sent, submittingNow(f))
+	}
+	if f.SetLocked(true); submittingNow(f) {
+		t.Error("disabled fields are not a submitting button")
+	}
+}
+
+func TestCancelSubmit_IgnoresLateDone(t *testing.T) {
+	f, _ := form.New("app", &submitStruct{Nombre: "Ann"}, &testIDGen{})
+	var dones []func(error)
+	succeeded := 0
+	f.OnSubmit(func(_ model.Fielder, d func(error)) { dones = append(dones, d) }).
+		AfterSuccess(func(model.Fielder) { succeeded++ })
+
+	f.Submit()
+	f.CancelSubmit()
+	if submittingNow(f) {
+		t.Error("CancelSubmit turns submitting off")
+	}
+	f.Submit()
+	dones[0](nil) // the cancelled attempt answers late
+	if succeeded != 0 || !submittingNow(f) {
+		t.Errorf("a late done acted: succeeded=%d submitting=%v", succeeded, submittingNow(f))
+	}
+	dones[1](nil)
+	if succeeded != 1 || submittingNow(f) {
+		t.Errorf("current attempt: succeeded=%d submitting=%v", succeeded, submittingNow(f))
+	}
+}
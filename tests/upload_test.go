@@ -0,0 +1,183 @@
+package form_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tinywasm/form"
+	"github.com/tinywasm/input"
+	"github.com/tinywasm/model"
+)
+
+type fakeFile struct {
+	name, mime string
+	size       int64
+}
+
+func (f fakeFile) Name() string { return f.name }
+func (f fakeFile) Size() int64  { return f.size }
+func (f fakeFile) MIME() string { return f.mime }
+
+// fakeUploader records each Upload so the test decides when it finishes.
+type fakeUploader struct {
+	progress []func(sent, total int64)
+	done     []func(ref string, err error)
+}
+
+func (u *fakeUploader) Upload(_ form.FileInfo, progress func(int64, int64), done func(string, error)) {
+	u.progress = append(u.progress, progress)
+	u.done = append(u.done, done)
+}
+
+type avatarRecord struct {
+	Name   string
+	Avatar string // the upload's reference
+}
+
+func (m *avatarRecord) Schema() []model.Field {
+	return []model.Field{
+		{Name: "Name", Type: input.Text()},
+		{Name: "Avatar", Type: form.Image(form.FileRules{
+			MaxSize:    1000,
+			PreviewURL: func(ref string) string { return "/files/" + ref },
+		})},
+	}
+}
+
+func (m *avatarRecord) Pointers() []any { return []any{&m.Name, &m.Avatar} }
+
+func TestUpload_RulesAndMetadata(t *testing.T) {
+	up := &fakeUploader{}
+	f, _ := form.New("parent", &avatarRecord{}, &testIDGen{}, form.WithUploader(up))
+
+	if err := f.SelectFile("Avatar", fakeFile{"big.png", "image/png", 5000}); err == nil {
+		t.Error("a file over MaxSize was accepted")
+	}
+	if err := f.SelectFile("Avatar", fakeFile{"doc.pdf", "application/pdf", 10}); err == nil {
+		t.Error("a PDF was accepted by an image field")
+	}
+	if len(up.done) != 0 || f.SelectedFile("Avatar") != nil {
+		t.Fatal("a rejected file must not be kept or uploaded")
+	}
+
+	if err := f.SelectFile("Avatar", fakeFile{"me.PNG", "Image/PNG", 10}); err != nil {
+		t.Fatalf("SelectFile: %v", err)
+	}
+	if got := f.SelectedFile("Avatar"); got == nil || got.Name() != "me.PNG" || got.Size() != 10 {
+		t.Errorf("SelectedFile = %v, want me.PNG (10 bytes)", got)
+	}
+	if !f.Uploading() {
+		t.Error("Uploading = false with an upload in flight")
+	}
+
+	up.progress[0](5, 10)
+	if html := f.String(); !strings.Contains(html, `value='50'`) {
+		t.Errorf("progress bar not at 50%%:\n%s", html)
+	}
+	up.done[0]("abc123", nil)
+
+	dst := &avatarRecord{}
+	f.SyncValues(dst)
+	if dst.Avatar != "abc123" {
+		t.Errorf("Avatar = %q, want the upload reference", dst.Avatar)
+	}
+	if html := f.String(); !strings.Contains(html, `src='/files/abc123'`) {
+		t.Errorf("preview does not show the stored reference:\n%s", html)
+	}
+}
+
+func TestUpload_SubmitWaitsForPendingUploads(t *testing.T) {
+	up := &fakeUploader{}
+	rec := &avatarRecord{Name: "Ana"}
+	f, _ := form.New("parent", rec, &testIDGen{}, form.WithUploader(up))
+	var sent string
+	f.OnSubmit(func(d model.Fielder, done func(error)) {
+		sent = d.(*avatarRecord).Avatar
+		done(nil)
+	})
+
+	f.SelectFile("Avatar", fakeFile{"a.png", "image/png", 10})
+	if err := f.Submit(); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if sent != "" {
+		t.Fatal("Submit ran before the upload finished")
+	}
+	up.done[0]("ref-a", nil)
+	if sent != "ref-a" {
+		t.Errorf("queued Submit sent Avatar=%q, want ref-a", sent)
+	}
+}
+
+func TestUpload_QueuedSubmitErrorReachesAfterError(t *testing.T) {
+	up := &fakeUploader{}
+	f, _ := form.New("parent", &avatarRecord{Name: "Ana"}, &testIDGen{}, form.WithUploader(up))
+	var got error
+	f.OnSubmit(func(model.Fielder, func(error)) { t.Error("an invalid form was sent") }).
+		AfterError(func(err error) { got = err })
+
+	f.SelectFile("Avatar", fakeFile{"a.png", "image/png", 10})
+	if err := f.Submit(); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	f.SetValues("Name", "A") // invalid by the time the upload lands
+	up.done[0]("ref-a", nil)
+	if got == nil {
+		t.Error("the deferred Submit's validation error was discarded")
+	}
+}
+
+func TestUpload_FailureDropsQueuedSubmit(t *testing.T) {
+	up := &fakeUploader{}
+	f, _ := form.New("parent", &avatarRecord{Name: "Ana"}, &testIDGen{}, form.WithUploader(up))
+	called := false
+	f.OnSubmit(func(model.Fielder, func(error)) { called = true })
+
+	f.SelectFile("Avatar", fakeFile{"a.png", "image/png", 10})
+	// A second pick supersedes the first: its late result is ignored.
+	f.SelectFile("Avatar", fakeFile{"b.png", "image/png", 10})
+	f.Submit()
+	up.done[0]("stale", nil)
+	up.done[1]("", errors.New("network down"))
+	if called {
+		t.Error("Submit ran although the upload failed")
+	}
+	if f.Uploading() {
+		t.Error("Uploading still true after every upload finished")
+	}
+	dst := &avatarRecord{}
+	f.SyncValues(dst)
+	if dst.Avatar != "" {
+		t.Errorf("Avatar = %q, want empty (stale upload must not land)", dst.Avatar)
+	}
+}
+
+func TestUpload_ResetAndLoadDropQueuedSubmit(t *testing.T) {
+	for name, drop := range map[string]func(*form.Form){
+		"Reset":      func(f *form.Form) { f.Reset() },
+		"LoadValues": func(f *form.Form) { f.LoadValues(&avatarRecord{Name: "Bea"}) },
+	} {
+		up := &fakeUploader{}
+		f, _ := form.New("parent", &avatarRecord{Name: "Ana"}, &testIDGen{}, form.WithUploader(up))
+		sent, failed := false, false
+		f.OnSubmit(func(model.Fielder, func(error)) { sent = true }).
+			AfterError(func(error) { failed = true })
+
+		f.SelectFile("Avatar", fakeFile{"a.png", "image/png", 10})
+		f.Submit()
+		drop(f)
+		if submittingNow(f) || f.Uploading() {
+			t.Errorf("%s: submitting=%v uploading=%v, want both off", name, submittingNow(f), f.Uploading())
+		}
+		up.done[0]("ref-a", nil) // the abandoned upload lands late
+		if sent || failed || submittingNow(f) {
+			t.Errorf("%s: the abandoned Submit ran: sent=%v failed=%v", name, sent, failed)
+		}
+		dst := &avatarRecord{}
+		f.SyncValues(dst)
+		if dst.Avatar != "" {
+			t.Errorf("%s: Avatar = %q, the abandoned upload must not land", name, dst.Avatar)
+		}
+	}
+}
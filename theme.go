@@ -0,0 +1,66 @@
+package form
+
+// Theme carries extra CSS classes layered ON TOP of the widget.NameField
+// anatomy (tw-field, tw-field__label, …) — never instead of it, so a global
+// form skin keyed on the tw-* contract keeps working under any theme. Each
+// slot is a space-separated class list; empty adds nothing. LabelChars sets
+// how much of a label the chip shows.
+type Theme struct {
+	Form   string // the <form> element
+	Field  string // every field's root wrapper (and the submit button's)
+	Label  string // field labels
+	Input  string // input/textarea/select controls
+	Error  string // error spans
+	Submit string // the submit button
+
+	// LabelChars is the label budget in characters (runes), ellipsis
+	// included. Zero keeps the default (14, sized for the fieldset chip);
+	// NoTruncation shows every label whole.
+	LabelChars int
+}
+
+// NoTruncation, as Theme.LabelChars, turns label truncation off.
+const NoTruncation = -1
+
+// defaultTheme is what New starts every form from. Only SetDefaultTheme,
+// ResetDefaultTheme and the legacy SetGlobalClass write it.
+var defaultTheme Theme
+
+// WithTheme scopes a theme to one form, replacing the package default for it
+// alone — two apps (or two test cases) on the same page no longer bleed
+// classes into each other through package state.
+func WithTheme(t Theme) Option {
+	return func(f *Form) {
+		f.theme = t
+		f.class = t.Form
+	}
+}
+
+// SetDefaultTheme sets the theme every form created AFTERWARDS starts from.
+// Forms already built keep the theme they were built with.
+func SetDefaultTheme(t Theme) { defaultTheme = t }
+
+// ResetDefaultTheme clears the package default back to the bare
+// widget.NameField anatomy.
+func ResetDefaultTheme() { defaultTheme = Theme{} }
+
+// SetGlobalClass appends classes to the default theme's Form slot.
+// Kept for existing callers; new code states the whole default explicitly
+// with SetDefaultTheme, or scopes it per form with WithTheme.
+func SetGlobalClass(classes ...string) {
+	defaultTheme.Form = joinClass(defaultTheme.Form, classes...)
+}
+
+// joinClass appends the non-empty extras to base, space-separated.
+func joinClass(base string, extra ...string) string {
+	for _, c := range extra {
+		if c == "" {
+			continue
+		}
+		if base != "" {
+			base += " "
+		}
+		base += c
+	}
+	return base
+}
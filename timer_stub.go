@@ -0,0 +1,7 @@
+//go:build !wasm
+
+package form
+
+// defaultTimer is nil outside the browser: with no event loop to defer to,
+// debounced work runs at once (see WithTimer).
+func defaultTimer() Timer { return nil }
@@ -0,0 +1,29 @@
+//go:build wasm
+
+package form
+
+import "syscall/js"
+
+// jsTimer schedules with setTimeout.
+type jsTimer struct{}
+
+func defaultTimer() Timer { return jsTimer{} }
+
+func (jsTimer) AfterFunc(ms int, fn func()) func() {
+	done := false
+	var cb js.Func
+	cb = js.FuncOf(func(js.Value, []js.Value) any {
+		done = true
+		cb.Release()
+		fn()
+		return nil
+	})
+	id := js.Global().Call("setTimeout", cb, ms)
+	return func() {
+		if !done {
+			done = true
+			js.Global().Call("clearTimeout", id)
+			cb.Release()
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package form
+
+import "github.com/tinywasm/dom"
+
+// Trigger is when a field validates live, as the user edits it. Whatever
+// the trigger, Submit validates every field and shows its errors.
+type Trigger uint8
+
+const (
+	// ValidateOnInput validates on every keystroke (the default).
+	ValidateOnInput Trigger = iota
+	// ValidateOnCommit validates when the user commits the field: on
+	// leaving it, or on a select/radio change.
+	ValidateOnCommit
+	// ValidateOnSubmit leaves the field alone until Submit.
+	ValidateOnSubmit
+	// ValidateAfterBlur validates on commit, then on every keystroke once
+	// the field has been left — nobody is told "minimum 3 characters" after
+	// typing the first letter, and a shown error clears as soon as it is
+	// fixed.
+	ValidateAfterBlur
+)
+
+type fieldTrigger struct {
+	field string // "" = the form default
+	t     Trigger
+}
+
+// WithTrigger sets when the named fields validate live — or, with no field
+// names, every field without a trigger of its own. See Trigger.
+func WithTrigger(t Trigger, fields ...string) Option {
+	return func(f *Form) {
+		if len(fields) == 0 {
+			f.triggers = append(f.triggers, fieldTrigger{t: t})
+		}
+		for _, name := range fields {
+			f.triggers = append(f.triggers, fieldTrigger{field: name, t: t})
+		}
+	}
+}
+
+// resolveTrigger picks a field's trigger: its own, else the form default.
+func (f *Form) resolveTrigger(field string) Trigger {
+	t, own := ValidateOnInput, false
+	for _, ft := range f.triggers {
+		switch {
+		case ft.field == field:
+			t, own = ft.t, true
+		case ft.field == "" && !own:
+			t = ft.t
+		}
+	}
+	return t
+}
+
+// Touched returns the named field's touched signal: true once the user has
+// left the field (or Submit was tried), until the next Reset or LoadValues.
+// Nil for an unknown field.
+func (f *Form) Touched(fieldName string) *dom.SignalBool {
+	if i := f.inputIndex(fieldName); i >= 0 {
+		return f.touched[i]
+	}
+	return nil
+}
+
+// Visited returns the named field's visited signal: true once the field has
+// had focus, until the next Reset or LoadValues. Nil for an unknown field.
+func (f *Form) Visited(fieldName string) *dom.SignalBool {
+	if i := f.inputIndex(fieldName); i >= 0 {
+		return f.visited[i]
+	}
+	return nil
+}
+
+// untouch clears every field's touched and visited state.
+func (f *Form) untouch() {
+	for i := range f.touched {
+		f.touched[i].Set(false)
+		f.visited[i].Set(false)
+	}
+}
+
+// check runs live validation as the field's Trigger asks: on an edit
+// (commit false) or a commit — a select/radio change, a reference pick.
+func (fc *fieldComponent) check(val string, commit bool) {
+	switch fc.trigger {
+	case ValidateOnSubmit:
+		return
+	case ValidateOnCommit:
+		if !commit {
+			return
+		}
+	case ValidateAfterBlur:
+		if !commit && (fc.touched == nil || !fc.touched.Get()) {
+			return
+		}
+	}
+	if !commit && fc.delay > 0 {
+		fc.debounce(&fc.pendingCheck, fc.delay, func() { fc.validate(fc.value.Get()) })
+		return
+	}
+	fc.validate(val)
+}
+
+// enter and leave follow focus into and out of the field (focusin/focusout
+// on its wrapper, so radios and custom widgets count too). Leaving marks
+// the field touched and is a commit for the commit-driven triggers.
+func (fc *fieldComponent) enter() {
+	fc.visited.Set(true)
+	fc.active.Set(true)
+}
+
+func (fc *fieldComponent) leave() {
+	fc.active.Set(false)
+	fc.touched.Set(true)
+	switch {
+	case fc.trigger == ValidateOnCommit, fc.trigger == ValidateAfterBlur,
+		fc.pendingCheck != nil: // a debounced check runs now
+		fc.validate(fc.value.Get())
+	}
+}
@@ -0,0 +1,97 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/tinywasm/input"
+	"github.com/tinywasm/model"
+)
+
+type triggerRecord struct{ Name, Nick string }
+
+func (r *triggerRecord) Schema() []model.Field {
+	return []model.Field{
+		{Name: "Name", Type: input.Text()},
+		{Name: "Nick", Type: input.Text()},
+	}
+}
+
+func (r *triggerRecord) Pointers() []any { return []any{&r.Name, &r.Nick} }
+
+// typeInto mimics the user typing val into the field: the input handler.
+func typeInto(fc *fieldComponent, val string) {
+	fc.value.Set(val)
+	fc.check(val, false)
+}
+
+func TestTrigger_Modes(t *testing.T) {
+	cases := []struct {
+		trigger           Trigger
+		onType, onLeave   bool // error shown after typing "a", after leaving
+		afterLeaveRetyped bool // error shown after typing "b" once left
+	}{
+		{ValidateOnInput, true, true, true},
+		{ValidateOnCommit, false, true, true}, // the commit error stays until the next commit
+		{ValidateOnSubmit, false, false, false},
+		{ValidateAfterBlur, false, true, true},
+	}
+	for _, c := range cases {
+		f, err := New("app", &triggerRecord{}, &testIDGen{}, WithTrigger(c.trigger))
+		if err != nil {
+			t.Fatal(err)
+		}
+		fc := f.children[0].(*fieldComponent)
+		typeInto(fc, "a")
+		if got := f.errorSignals[0].Get() != ""; got != c.onType {
+			t.Errorf("trigger %d: error after typing = %v, want %v", c.trigger, got, c.onType)
+		}
+		fc.leave()
+		if got := f.errorSignals[0].Get() != ""; got != c.onLeave {
+			t.Errorf("trigger %d: error after leaving = %v, want %v", c.trigger, got, c.onLeave)
+		}
+		typeInto(fc, "ab")
+		typeInto(fc, "b")
+		if got := f.errorSignals[0].Get() != ""; got != c.afterLeaveRetyped {
+			t.Errorf("trigger %d: error retyping once left = %v, want %v", c.trigger, got, c.afterLeaveRetyped)
+		}
+	}
+}
+
+func TestTrigger_PerFieldAndTouched(t *testing.T) {
+	f, _ := New("app", &triggerRecord{}, &testIDGen{},
+		WithTrigger(ValidateOnSubmit), WithTrigger(ValidateOnInput, "Nick"))
+	name, nick := f.children[0].(*fieldComponent), f.children[1].(*fieldComponent)
+	if name.trigger != ValidateOnSubmit || nick.trigger != ValidateOnInput {
+		t.Fatalf("triggers = %d, %d", name.trigger, nick.trigger)
+	}
+
+	name.enter()
+	if !f.Visited("Name").Get() || f.Touched("Name").Get() {
+		t.Error("focused, not yet left: visited only")
+	}
+	if !f.Focused("Name").Get() || f.State().Focused != "Name" {
+		t.Error("Name holds focus")
+	}
+	name.leave()
+	if !f.Touched("Name").Get() || f.Touched("Nick").Get() {
+		t.Error("leaving Name touches it alone")
+	}
+	if f.Focused("Name").Get() || f.State().Focused != "" {
+		t.Error("focus left Name")
+	}
+	if f.Touched("nope") != nil {
+		t.Error("unknown field: nil signal")
+	}
+
+	if err := f.Submit(); err == nil {
+		t.Fatal("empty fields should fail")
+	}
+	if f.errorSignals[0].Get() == "" || !f.Touched("Nick").Get() {
+		t.Error("a failed Submit shows every error and touches every field")
+	}
+
+	f.Reset()
+	if f.Touched("Name").Get() || f.Visited("Name").Get() {
+		t.Error("Reset clears touched and visited")
+	}
+}
@@ -0,0 +1,282 @@
+package form
+
+import (
+	"github.com/tinywasm/dom"
+	"github.com/tinywasm/fmt"
+	"github.com/tinywasm/input"
+	"github.com/tinywasm/widget"
+)
+
+// FileInfo is a file the user picked in a file field — the metadata the form
+// checks against FileRules and keeps for the host (see SelectedFile). In the
+// browser the value also has JSValue() js.Value, the DOM File itself, which
+// is what an Uploader streams; tests pass their own implementation.
+type FileInfo interface {
+	Name() string
+	Size() int64
+	MIME() string
+}
+
+// Uploader sends a picked file to wherever files live. It reports progress
+// as it goes (total may be 0 when unknown) and calls done exactly once with
+// the reference the form writes into the field — a URL, object key or id,
+// whatever the bound string field stores.
+type Uploader interface {
+	Upload(file FileInfo, progress func(sent, total int64), done func(ref string, err error))
+}
+
+// WithUploader injects the Uploader every file field of the form uses.
+func WithUploader(u Uploader) Option {
+	return func(f *Form) {
+		f.uploader = u
+	}
+}
+
+// FileRules constrain what a file field accepts.
+type FileRules struct {
+	// MaxSize is the largest file accepted, in bytes. Zero means no limit.
+	MaxSize int64
+	// Accept lists the MIME types accepted: exact ("application/pdf") or a
+	// wildcard ("image/*"). Empty accepts any type. Also emitted as the
+	// control's accept attribute, so the picker filters the same way.
+	Accept []string
+	// Preview shows an <img> of the picked file (Image turns it on).
+	Preview bool
+	// PreviewURL maps a stored reference back to a URL the preview can show,
+	// so a loaded record's image appears before anything is picked. Nil
+	// shows a preview only for a freshly picked file.
+	PreviewURL func(ref string) string
+}
+
+// fileInput is the input behind File and Image. Its value — what the bound
+// string field stores — is the reference the Uploader returned, never the
+// file's bytes. Each Clone carries its own selection state.
+type fileInput struct {
+	input.Base
+	rules    FileRules
+	file     FileInfo          // last accepted pick, nil when none
+	seq      int               // bumps per pick; a stale upload's done is ignored
+	local    *dom.SignalString // preview URL of the picked file ("" = none)
+	progress *dom.SignalString // upload percentage, "" when idle
+}
+
+// File creates a file input whose value is the Uploader's reference for the
+// picked file. Its field must store text.
+func File(rules FileRules) input.Input {
+	fi := &fileInput{rules: rules}
+	fi.InitBase("", "", "file")
+	return fi
+}
+
+// Image is File restricted to images (unless rules.Accept says otherwise),
+// with a preview.
+func Image(rules FileRules) input.Input {
+	if len(rules.Accept) == 0 {
+		rules.Accept = []string{"image/*"}
+	}
+	rules.Preview = true
+	return File(rules)
+}
+
+func (fi *fileInput) Clone(parentID, name string) input.Input {
+	c := *fi
+	c.InitBase(parentID, name, "file")
+	c.file, c.seq = nil, 0
+	c.local = dom.NewString("")
+	c.progress = dom.NewString("")
+	return &c
+}
+
+// Validate checks the stored reference: only presence, when required — the
+// file itself was checked against the rules when it was picked.
+func (fi *fileInput) Validate(value string) error {
+	if value == "" && fi.Required {
+		return errRequired(fi.FieldName())
+	}
+	return nil
+}
+
+// check applies the rules to a picked file.
+func (fi *fileInput) check(file FileInfo) error {
+	if fi.rules.MaxSize > 0 && file.Size() > fi.rules.MaxSize {
+		return message(KeyErrorFileSize, fi.FieldName(), file.Name(), fmt.Convert(fi.rules.MaxSize).String(),
+			"file", file.Name(), "exceeds", fi.rules.MaxSize, "bytes")
+	}
+	if len(fi.rules.Accept) == 0 {
+		return nil
+	}
+	for _, a := range fi.rules.Accept {
+		if mimeMatches(a, file.MIME()) {
+			return nil
+		}
+	}
+	return message(KeyErrorFileType, fi.FieldName(), file.MIME(), "", "file", "type", file.MIME(), "NotAllowed")
+}
+
+// clear drops the picked file and its preview (a reset or a newly loaded
+// record); an upload still in flight is orphaned by the seq bump.
+func (fi *fileInput) clear() {
+	if fi.file != nil {
+		revokePreview(fi.local.Get())
+	}
+	fi.file = nil
+	fi.seq++
+	fi.local.Set("")
+	fi.progress.Set("")
+}
+
+// mimeMatches reports whether mime satisfies one accept entry ("image/*"
+// or an exact type; case-insensitive).
+func mimeMatches(accept, mime string) bool {
+	accept, mime = fmt.Convert(accept).ToLower().String(), fmt.Convert(mime).ToLower().String()
+	if fmt.HasSuffix(accept, "/*") {
+		return fmt.HasPrefix(mime, accept[:len(accept)-1])
+	}
+	return accept == mime
+}
+
+// SelectFile is what picking a file in the named file field does: check it
+// against the field's FileRules, keep its metadata and preview, and start the
+// upload. The field's value becomes the returned reference once the upload
+// is done; until then Submit waits (see Submit). A file breaking the rules
+// is reported on the field's error signal and returned, and nothing starts.
+func (f *Form) SelectFile(fieldName string, file FileInfo) error {
+	i := f.inputIndex(fieldName)
+	if i < 0 {
+		return fmt.Errf("form.SelectFile: no field %q", fieldName)
+	}
+	fi, ok := f.Inputs[i].(*fileInput)
+	if !ok {
+		return fmt.Errf("form.SelectFile: %q is not a file field", fieldName)
+	}
+	if err := fi.check(file); err != nil {
+		f.setError(i, err)
+		return err
+	}
+	if f.uploader == nil {
+		return fmt.Errf("form.SelectFile: no Uploader — pass WithUploader to New")
+	}
+	fi.clear()
+	fi.file = file
+	fi.local.Set(previewURL(file))
+	fi.progress.Set("0")
+	f.errorSignals[i].Set("")
+	seq, epoch := fi.seq, f.uploadEpoch
+	f.pendingUploads++
+
+	f.uploader.Upload(file, func(sent, total int64) {
+		if seq != fi.seq || total <= 0 {
+			return
+		}
+		fi.progress.Set(fmt.Convert(sent * 100 / total).String())
+	}, func(ref string, err error) {
+		if epoch != f.uploadEpoch {
+			return // abandoned by a reset, LoadValues or Dispose
+		}
+		f.pendingUploads--
+		if seq == fi.seq {
+			fi.progress.Set("")
+			if err != nil {
+				f.setError(i, err)
+				f.uploadFailed = true
+			} else {
+				f.SetValues(fieldName, ref)
+				f.errorSignals[i].Set("")
+			}
+		}
+		f.uploadSettled()
+	})
+	return nil
+}
+
+// uploadSettled runs the Submit that was waiting on uploads once the last one
+// is done — unless one of them failed, in which case the submission is
+// dropped and the field shows why. No caller is left to take that Submit's
+// error, so it goes to AfterError.
+func (f *Form) uploadSettled() {
+	if f.pendingUploads > 0 {
+		return
+	}
+	queued, failed := f.submitQueued, f.uploadFailed
+	f.submitQueued, f.uploadFailed = false, false
+	if !queued {
+		return
+	}
+	f.submitting.Set(false)
+	if failed {
+		return
+	}
+	if err := f.Submit(); err != nil && f.afterError != nil {
+		f.afterError(err)
+	}
+}
+
+// dropUploads abandons every upload in flight and the Submit queued behind
+// them (a reset, LoadValues or Dispose): their done no longer counts, and
+// the submit button leaves its loading state.
+func (f *Form) dropUploads() {
+	f.uploadEpoch++
+	f.pendingUploads, f.uploadFailed = 0, false
+	if f.submitQueued {
+		f.CancelSubmit()
+	}
+}
+
+// SelectedFile returns the file last picked in the named field, or nil.
+func (f *Form) SelectedFile(fieldName string) FileInfo {
+	if fi, ok := f.Input(fieldName).(*fileInput); ok && fi.file != nil {
+		return fi.file
+	}
+	return nil
+}
+
+// Uploading reports whether any upload of the form is still in flight.
+func (f *Form) Uploading() bool { return f.pendingUploads > 0 }
+
+// renderFile builds a file field's controls: the picker, an upload progress
+// bar shown while uploading and, with Preview, the image preview.
+func (fc *fieldComponent) renderFile(fi *fileInput) []*dom.Element {
+	el := dom.NewElement("input").
+		Attr("type", "file").
+		ID(fc.Input.GetID()).
+		Class(joinClass(widget.NameField.Class(widget.PartInput).String(), fc.th().Input)).
+		Attr("name", fc.Input.FieldName())
+	if len(fi.rules.Accept) > 0 {
+		el.Attr("accept", fmt.JoinSlice(fi.rules.Accept, ","))
+	}
+	el.On("change", func(e dom.Event) {
+		file := eventFile(e)
+		if file == nil || fc.selectFile == nil {
+			return // dialog cancelled: keep the current value
+		}
+		fc.selectFile(file)
+		if fc.onCommit != nil {
+			fc.onCommit()
+		}
+	})
+	applyCommonAttrs(el, fc)
+
+	controls := []*dom.Element{el, dom.NewElement("progress").
+		Class(widget.NameField.Class("progress").String()).
+		Attr("max", "100").
+		BindAttrFunc("value", fi.progress.Get).
+		BindAttrBoolFunc("hidden", func() bool { return fi.progress.Get() == "" })}
+
+	if fi.rules.Preview {
+		src := func() string {
+			if u := fi.local.Get(); u != "" {
+				return u
+			}
+			if ref := fc.value.Get(); ref != "" && fi.rules.PreviewURL != nil {
+				return fi.rules.PreviewURL(ref)
+			}
+			return ""
+		}
+		controls = append(controls, dom.NewElement("img").
+			Class(widget.NameField.Class("preview").String()).
+			Attr("alt", "").
+			BindAttrFunc("src", src).
+			BindAttrBoolFunc("hidden", func() bool { return src() == "" }))
+	}
+	return controls
+}
@@ -0,0 +1,14 @@
+//go:build !wasm
+
+package form
+
+import "github.com/tinywasm/dom"
+
+// Outside the browser there is no file picker: files reach a form only
+// through SelectFile, and nothing has an object URL to preview.
+
+func eventFile(dom.Event) FileInfo { return nil }
+
+func previewURL(FileInfo) string { return "" }
+
+func revokePreview(string) {}
@@ -0,0 +1,49 @@
+//go:build wasm
+
+package form
+
+import (
+	"syscall/js"
+
+	"github.com/tinywasm/dom"
+)
+
+// jsFile is a DOM File picked in a file field. JSValue exposes it so an
+// Uploader can hand it to fetch/XMLHttpRequest as is.
+type jsFile struct{ v js.Value }
+
+func (f jsFile) Name() string      { return f.v.Get("name").String() }
+func (f jsFile) Size() int64       { return int64(f.v.Get("size").Int()) }
+func (f jsFile) MIME() string      { return f.v.Get("type").String() }
+func (f jsFile) JSValue() js.Value { return f.v }
+
+// eventFile returns the first file of a change event's target, or nil when
+// the picker was cancelled.
+func eventFile(e dom.Event) FileInfo {
+	ev, ok := jsEvent(e)
+	if !ok {
+		return nil
+	}
+	files := ev.Get("target").Get("files")
+	if files.IsUndefined() || files.IsNull() || files.Length() == 0 {
+		return nil
+	}
+	return jsFile{files.Index(0)}
+}
+
+// previewURL makes an object URL for a picked browser file ("" for any other
+// FileInfo, e.g. a host's own implementation).
+func previewURL(file FileInfo) string {
+	f, ok := file.(jsFile)
+	if !ok {
+		return ""
+	}
+	return js.Global().Get("URL").Call("createObjectURL", f.v).String()
+}
+
+// revokePreview releases an object URL made by previewURL.
+func revokePreview(url string) {
+	if url != "" {
+		js.Global().Get("URL").Call("revokeObjectURL", url)
+	}
+}
@@ -2,25 +2,31 @@ package form
 
 // Validate validates all inputs and returns the first error found.
 func (f *Form) Validate() error {
-	for i, inp := range f.Inputs {
-		// Skip validation if requested via tag
-		if skipper, ok := inp.(interface{ GetSkipValidation() bool }); ok && skipper.GetSkipValidation() {
-			continue
+	for i := range f.Inputs {
+		if err := f.validateAt(i); err != nil {
+			return err
 		}
+	}
+	return nil
+}
 
-		// Signal is the source of truth in WASM mode.
-		val := f.valueSignals[i].Get()
+// validateAt validates the i-th input's current value.
+func (f *Form) validateAt(i int) error {
+	inp := f.Inputs[i]
+	// Skip validation if requested via tag
+	if skipper, ok := inp.(interface{ GetSkipValidation() bool }); ok && skipper.GetSkipValidation() {
+		return nil
+	}
 
-		// Fallback only if we are somehow in SSR mode where signals might be empty
-		if val == "" && f.ssrMode {
-			if valuer, ok := inp.(interface{ GetSelectedValue() string }); ok {
-				val = valuer.GetSelectedValue()
-			}
-		}
+	// Signal is the source of truth in WASM mode.
+	val := f.valueSignals[i].Get()
 
-		if err := inp.Validate(val); err != nil {
-			return err
+	// Fallback only if we are somehow in SSR mode where signals might be empty
+	if val == "" && f.ssrMode {
+		if valuer, ok := inp.(interface{ GetSelectedValue() string }); ok {
+			val = valuer.GetSelectedValue()
 		}
 	}
-	return nil
+
+	return f.validateField(i, val, f.data, true)
 }
@@ -1,24 +1,24 @@
 package form
 
-import (
-	"github.com/tinywasm/fmt"
-	"github.com/tinywasm/model"
-)
+import "github.com/tinywasm/model"
 
-// ValidateData validates a Fielder instance using this form's input rules.
-// Satisfies the updated crudp.DataValidator interface (with model.Fielder).
+// ValidateData validates a Fielder instance using this form's input rules,
+// for the given CRUD action (model.ActionCreate, …): RequireOn/SkipOn adjust
+// the rules per action, and a delete skips field validation altogether
+// except for the fields RequireOn names for it. Satisfies the updated
+// crudp.DataValidator interface (with model.Fielder).
 func (f *Form) ValidateData(action byte, data model.Fielder) error {
-	values := model.ReadValues(data.Schema(), data.Pointers())
+	schema, pointers := data.Schema(), data.Pointers()
 	for i, inp := range f.Inputs {
 		idx := f.fieldIndices[i]
-		if idx < 0 || idx >= len(values) {
+		if idx < 0 || idx >= len(pointers) {
 			continue
 		}
 		if skipper, ok := inp.(interface{ GetSkipValidation() bool }); ok && skipper.GetSkipValidation() {
 			continue
 		}
-		val := fmt.Convert(values[idx]).String()
-		if err := inp.Validate(val); err != nil {
+		val := f.toDisplay(i, readField(pointers[idx], schema[idx].Type.Storage()))
+		if err := f.validateAction(action, i, val, data); err != nil {
 			return err
 		}
 	}